@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AdlistsResource{}
+var _ resource.ResourceWithValidateConfig = &AdlistsResource{}
+var _ resource.ResourceWithImportState = &AdlistsResource{}
+
+// listTypes are the kinds of lists gravity subscribes to
+var listTypes = []string{"block", "allow"}
+
+func NewAdlistsResource() resource.Resource {
+	return &AdlistsResource{}
+}
+
+type AdlistsResource struct {
+	client *PiholeClient
+}
+
+type AdlistsResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Lists         []AdlistsEntry `tfsdk:"lists"`
+	UpdateGravity types.Bool     `tfsdk:"update_gravity"`
+}
+
+type AdlistsEntry struct {
+	Address types.String `tfsdk:"address"`
+	Type    types.String `tfsdk:"type"`
+	Comment types.String `tfsdk:"comment"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *AdlistsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_adlists"
+}
+
+func (r *AdlistsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of subscribed lists, adlists as well as allowlists, as one unit. " +
+			"All lists are read with one request and changes are applied in batches, followed by at most one gravity " +
+			"update. Lists that are not in the set are left alone. Deleting this resource removes only the lists it manages.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Adlists identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"lists": schema.SetNestedAttribute{
+				MarkdownDescription: "Lists to subscribe to",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							MarkdownDescription: "URL of the list",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Kind of list, `block` for an adlist or `allow` for an allowlist (default: `block`)",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("block"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(listTypes...),
+							},
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Comment shown next to the list in the web interface",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether gravity uses the list (default: true)",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"update_gravity": schema.BoolAttribute{
+				MarkdownDescription: "Whether to update gravity once after the lists were changed, which is needed before " +
+					"the change affects DNS answers (default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *AdlistsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A list that differs only in its comment or enabled flag would be a second set element for the same list
+	seen := make(map[string]bool, len(data.Lists))
+	for _, entry := range data.Lists {
+		if entry.Address.IsUnknown() || entry.Type.IsUnknown() {
+			continue
+		}
+
+		list := entry.list()
+		if seen[listKey(list)] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lists"),
+				"Duplicate List",
+				fmt.Sprintf("The %s list '%s' is configured more than once.", list.Type, list.Address),
+			)
+		}
+		seen[listKey(list)] = true
+	}
+}
+
+func (r *AdlistsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AdlistsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data.Lists, nil, data.UpdateGravity.ValueBool(), &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create lists, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lists that dropped out of the set are no longer managed and get removed
+	planned := make(map[string]bool, len(data.Lists))
+	for _, entry := range data.Lists {
+		planned[listKey(entry.list())] = true
+	}
+	var removed []AdlistsEntry
+	for _, entry := range state.Lists {
+		if !planned[listKey(entry.list())] {
+			removed = append(removed, entry)
+		}
+	}
+
+	if err := r.apply(ctx, data.Lists, removed, data.UpdateGravity.ValueBool(), &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lists, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AdlistsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, nil, data.Lists, data.UpdateGravity.ValueBool(), &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lists, got error: %s", err))
+		return
+	}
+}
+
+func (r *AdlistsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importedTypes := strings.Split(req.ID, ",")
+	for _, listType := range importedTypes {
+		if !slices.Contains(listTypes, listType) {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected an import ID of comma separated list types (e.g. 'block' or 'block,allow'), got: %s", req.ID),
+			)
+			return
+		}
+	}
+
+	lists, err := r.client.GetLists()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lists, got error: %s", err))
+		return
+	}
+
+	data := AdlistsResourceModel{
+		ID:            types.StringValue("adlists"),
+		Lists:         []AdlistsEntry{},
+		UpdateGravity: types.BoolValue(false),
+	}
+	for _, list := range lists {
+		if slices.Contains(importedTypes, list.Type) {
+			data.Lists = append(data.Lists, adlistsEntry(list))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply brings the lists in line with the given entries and optionally updates gravity so the change takes effect.
+// The lists are changed even if gravity fails to update, so that is reported as a warning rather than an error.
+func (r *AdlistsResource) apply(ctx context.Context, desired, removed []AdlistsEntry, updateGravity bool, diags *diag.Diagnostics) error {
+	desiredLists := make([]List, 0, len(desired))
+	for _, entry := range desired {
+		desiredLists = append(desiredLists, entry.list())
+	}
+	removedLists := make([]List, 0, len(removed))
+	for _, entry := range removed {
+		removedLists = append(removedLists, entry.list())
+	}
+
+	changed, err := r.client.ApplyLists(ctx, desiredLists, removedLists)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	// Without an explicit update, the provider's auto_gravity decides whether gravity is updated
+	if !updateGravity {
+		err = r.client.ScheduleGravityUpdate(ctx)
+	} else {
+		gravityCtx, cancel := context.WithTimeout(ctx, defaultGravityTimeoutSeconds*time.Second)
+		defer cancel()
+
+		err = r.client.UpdateGravity(gravityCtx)
+	}
+	if err != nil {
+		diags.AddWarning("Gravity Update Failed",
+			fmt.Sprintf("The lists were changed, but the gravity update failed: %s. "+
+				"Gravity is updated again with the next change of the lists, or run a gravity update manually.", err))
+	}
+
+	return nil
+}
+
+// readInto reconciles the managed lists with Pi-hole. Lists removed outside of Terraform drop out of the set,
+// so the next plan adds them again.
+func (r *AdlistsResource) readInto(data *AdlistsResourceModel) error {
+	lists, err := r.client.GetLists()
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]List, len(lists))
+	for _, list := range lists {
+		current[listKey(list)] = list
+	}
+
+	entries := make([]AdlistsEntry, 0, len(data.Lists))
+	for _, entry := range data.Lists {
+		if list, exists := current[listKey(entry.list())]; exists {
+			entries = append(entries, adlistsEntry(list))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return listKey(entries[i].list()) < listKey(entries[j].list())
+	})
+
+	data.ID = types.StringValue("adlists")
+	data.Lists = entries
+
+	return nil
+}
+
+// list converts the entry to the client's representation
+func (e AdlistsEntry) list() List {
+	return List{
+		Address: e.Address.ValueString(),
+		Type:    e.Type.ValueString(),
+		Comment: e.Comment.ValueString(),
+		Enabled: e.Enabled.ValueBool(),
+	}
+}
+
+// adlistsEntry converts a list as returned by Pi-hole to an entry of the set
+func adlistsEntry(list List) AdlistsEntry {
+	return AdlistsEntry{
+		Address: types.StringValue(list.Address),
+		Type:    types.StringValue(list.Type),
+		Comment: types.StringValue(list.Comment),
+		Enabled: types.BoolValue(list.Enabled),
+	}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var adlistsEntryType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"address": tftypes.String,
+	"type":    tftypes.String,
+	"comment": tftypes.String,
+	"enabled": tftypes.Bool,
+}}
+
+// adlistsValue builds the lists attribute with all entry attributes set, as they are planned after the defaults
+func adlistsValue(lists ...List) tftypes.Value {
+	entries := make([]tftypes.Value, 0, len(lists))
+	for _, list := range lists {
+		entries = append(entries, tftypes.NewValue(adlistsEntryType, map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, list.Address),
+			"type":    tftypes.NewValue(tftypes.String, list.Type),
+			"comment": tftypes.NewValue(tftypes.String, list.Comment),
+			"enabled": tftypes.NewValue(tftypes.Bool, list.Enabled),
+		}))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: adlistsEntryType}, entries)
+}
+
+// storedLists returns the lists of the fake by address
+func storedLists(server *fakePihole) map[string]List {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	lists := make(map[string]List, len(server.lists))
+	for _, list := range server.lists {
+		lists[list.Address] = list
+	}
+	return lists
+}
+
+func TestAdlistsResource_Metadata(t *testing.T) {
+	r := NewAdlistsResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_adlists" {
+		t.Errorf("Expected TypeName to be 'pihole_adlists', got '%s'", resp.TypeName)
+	}
+}
+
+func TestAdlistsResource_CreateAddsListsInBatches(t *testing.T) {
+	server := newAdlistsFake(t)
+
+	r := NewAdlistsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"lists": adlistsValue(
+			List{Address: "https://example.org/ads.txt", Type: "block", Enabled: true},
+			List{Address: "https://example.org/trackers.txt", Type: "block", Enabled: true},
+			List{Address: "https://example.org/allow.txt", Type: "allow", Comment: "false positives", Enabled: true},
+		),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	// The two adlists share type, comment and enabled flag, so they are added in one request
+	if count := server.requestCount("POST /api/lists"); count != 2 {
+		t.Errorf("Expected 2 requests to add the lists, got %d", count)
+	}
+
+	stored := storedLists(server)
+	if len(stored) != 6 {
+		t.Fatalf("Expected the 3 new lists next to the 3 existing ones, got %v", stored)
+	}
+	if allow := stored["https://example.org/allow.txt"]; allow.Type != "allow" || allow.Comment != "false positives" {
+		t.Errorf("Expected the allowlist with its comment, got %+v", allow)
+	}
+
+	var data AdlistsResourceModel
+	resp.State.Get(context.Background(), &data)
+	if data.ID.ValueString() != "adlists" || len(data.Lists) != 3 {
+		t.Errorf("Expected the 3 managed lists in state, got %+v", data)
+	}
+}
+
+func TestAdlistsResource_GravityFailureKeepsState(t *testing.T) {
+	fake := newAdlistsFake(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/action/gravity" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	r := NewAdlistsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"lists":          adlistsValue(List{Address: "https://example.org/ads.txt", Type: "block", Enabled: true}),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	// The list was created, so it must stay in state even though gravity didn't update
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected only a warning for the failed gravity update, got errors: %v", resp.Diagnostics.Errors())
+	}
+	if warnings := resp.Diagnostics.Warnings(); len(warnings) != 1 || warnings[0].Summary() != "Gravity Update Failed" {
+		t.Errorf("Expected a 'Gravity Update Failed' warning, got %v", warnings)
+	}
+
+	var data AdlistsResourceModel
+	resp.State.Get(context.Background(), &data)
+	if len(data.Lists) != 1 {
+		t.Errorf("Expected the created list in state, got %+v", data.Lists)
+	}
+}
+
+func TestAdlistsResource_UpdateAddsChangesAndRemovesLists(t *testing.T) {
+	server := newAdlistsFake(t)
+
+	r := NewAdlistsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	hosts := List{Address: "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts", Type: "block", Enabled: true}
+	ads := List{Address: "https://example.com/lists/ads.txt?format=hosts", Type: "block", Enabled: true}
+	state := map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "adlists"),
+		"lists":          adlistsValue(hosts, ads),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	}
+
+	disabledHosts := hosts
+	disabledHosts.Enabled = false
+	disabledHosts.Comment = "too broad"
+	added := List{Address: "https://example.org/ads.txt", Type: "block", Enabled: true}
+
+	resp := testResourceUpdate(t, r, state, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "adlists"),
+		"lists":          adlistsValue(disabledHosts, added),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	stored := storedLists(server)
+	if list := stored[hosts.Address]; list.Enabled || list.Comment != "too broad" {
+		t.Errorf("Expected the hosts list to be disabled with a comment, got %+v", list)
+	}
+	if _, exists := stored[added.Address]; !exists {
+		t.Error("Expected the new list to be added")
+	}
+	if _, exists := stored[ads.Address]; exists {
+		t.Error("Expected the list dropped from the set to be removed")
+	}
+	// The allowlist was never managed by the resource
+	if _, exists := stored["https://example.com/lists/allow.txt"]; !exists {
+		t.Error("Expected the unmanaged allowlist to be left alone")
+	}
+	if count := server.requestCount("POST /api/lists:batchDelete"); count != 1 {
+		t.Errorf("Expected a single batch delete, got %d", count)
+	}
+}
+
+func TestAdlistsResource_DeleteRemovesOnlyManagedLists(t *testing.T) {
+	server := newAdlistsFake(t)
+
+	r := NewAdlistsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "adlists"),
+		"lists": adlistsValue(
+			List{Address: "https://example.com/lists/ads.txt?format=hosts", Type: "block", Enabled: true},
+			// Already removed outside of Terraform
+			List{Address: "https://example.org/gone.txt", Type: "block", Enabled: true},
+		),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	stored := storedLists(server)
+	if len(stored) != 2 {
+		t.Errorf("Expected the 2 unmanaged lists to remain, got %v", stored)
+	}
+	if _, exists := stored["https://example.com/lists/ads.txt?format=hosts"]; exists {
+		t.Error("Expected the managed list to be removed")
+	}
+}
+
+func TestAdlistsResource_ImportMatchesConfiguration(t *testing.T) {
+	server := newAdlistsFake(t)
+
+	r := NewAdlistsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	schemaResp, raw := testResourceObject(t, r, nil)
+	resp := &fwresource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+	}
+	r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: "block"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	// Refresh the imported state like Terraform does before planning
+	readResp := &fwresource.ReadResponse{State: resp.State}
+	r.Read(context.Background(), fwresource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+	}
+
+	// A configuration that only lists the addresses plans the same value, so the plan is clean
+	configured := adlistsValue(
+		List{Address: "https://example.com/lists/ads.txt?format=hosts", Type: "block", Enabled: true},
+		List{Address: "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts", Type: "block", Enabled: true},
+	)
+	lists, _, err := tftypes.WalkAttributePath(readResp.State.Raw, tftypes.NewAttributePath().WithAttributeName("lists"))
+	if err != nil {
+		t.Fatalf("Failed to get lists from state: %v", err)
+	}
+	if !lists.(tftypes.Value).Equal(configured) {
+		t.Errorf("Expected the imported lists to match the configuration, got %v", lists)
+	}
+
+	var updateGravity bool
+	readResp.State.GetAttribute(context.Background(), path.Root("update_gravity"), &updateGravity)
+	if updateGravity {
+		t.Error("Expected update_gravity to be imported with its default false")
+	}
+
+	invalid := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw}}
+	r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: "adlists"}, invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("Expected an import ID that is not a list type to be rejected")
+	}
+}
+
+func TestAdlistsResource_RejectsDuplicateLists(t *testing.T) {
+	r := NewAdlistsResource()
+
+	resp := testResourceValidateConfig(t, r, map[string]tftypes.Value{
+		"lists": adlistsValue(
+			List{Address: "https://example.org/ads.txt", Type: "block", Enabled: true},
+			List{Address: "https://example.org/ads.txt", Type: "block", Comment: "again", Enabled: true},
+			List{Address: "https://example.org/ads.txt", Type: "allow", Enabled: true},
+		),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("Expected one error for the duplicate adlist, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AdlistsToggleResource{}
+
+func NewAdlistsToggleResource() resource.Resource {
+	return &AdlistsToggleResource{}
+}
+
+type AdlistsToggleResource struct {
+	client *PiholeClient
+}
+
+type AdlistsToggleResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	UpdateGravity types.Bool   `tfsdk:"update_gravity"`
+}
+
+func (r *AdlistsToggleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_adlists_toggle"
+}
+
+func (r *AdlistsToggleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enables or disables all adlists at once, e.g. to rule out blocking while troubleshooting. " +
+			"Allowlists are not changed. Deleting this resource enables all adlists again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Adlists toggle identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether all adlists are enabled (`true`) or disabled (`false`)",
+				Required:            true,
+			},
+			"update_gravity": schema.BoolAttribute{
+				MarkdownDescription: "Whether to update gravity after the adlists were changed, which is needed before " +
+					"the change affects DNS answers (default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *AdlistsToggleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AdlistsToggleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AdlistsToggleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data.Enabled.ValueBool(), data.UpdateGravity.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set adlists, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read adlists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsToggleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AdlistsToggleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read adlists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsToggleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AdlistsToggleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data.Enabled.ValueBool(), data.UpdateGravity.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update adlists, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read adlists, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdlistsToggleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AdlistsToggleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Blocking is the normal state of a Pi-hole, so removing the toggle turns all adlists back on
+	if err := r.apply(ctx, true, data.UpdateGravity.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable adlists, got error: %s", err))
+		return
+	}
+}
+
+// apply switches all adlists and optionally updates gravity so the change takes effect
+func (r *AdlistsToggleResource) apply(ctx context.Context, enabled, updateGravity bool) error {
+	if err := r.client.SetAllListsEnabled(ctx, enabled); err != nil {
+		return err
+	}
+
+	// Without an explicit update, the provider's auto_gravity decides whether gravity is updated
+	if !updateGravity {
+		return r.client.ScheduleGravityUpdate(ctx)
+	}
+
+	gravityCtx, cancel := context.WithTimeout(ctx, defaultGravityTimeoutSeconds*time.Second)
+	defer cancel()
+
+	return r.client.UpdateGravity(gravityCtx)
+}
+
+// readInto reconciles the model with the aggregate state of the adlists in Pi-hole
+func (r *AdlistsToggleResource) readInto(data *AdlistsToggleResourceModel) error {
+	lists, err := r.client.GetLists()
+	if err != nil {
+		return err
+	}
+
+	adlists, enabledCount := 0, 0
+	for _, list := range lists {
+		if list.Type != "block" {
+			continue
+		}
+		adlists++
+		if list.Enabled {
+			enabledCount++
+		}
+	}
+
+	data.ID = types.StringValue("adlists_toggle")
+
+	switch {
+	case adlists == 0:
+		// Without any adlists both states are true, so the configured one is kept
+	case enabledCount == adlists:
+		data.Enabled = types.BoolValue(true)
+	case enabledCount == 0:
+		data.Enabled = types.BoolValue(false)
+	default:
+		// Some adlists were toggled outside of Terraform. Neither state matches, so the opposite of the
+		// configured one is reported and the next apply switches all adlists again.
+		data.Enabled = types.BoolValue(!data.Enabled.ValueBool())
+	}
+
+	return nil
+}
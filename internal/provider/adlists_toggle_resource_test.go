@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newAdlistsFake serves two adlists, one of them with a query string in its URL, and an allowlist
+func newAdlistsFake(t *testing.T) *fakePihole {
+	t.Helper()
+
+	server := newFakePihole(map[string]map[string]interface{}{})
+	t.Cleanup(server.Close)
+
+	server.setLists(
+		List{ID: 1, Address: "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts", Type: "block", Groups: []int64{0}, Enabled: true},
+		List{ID: 2, Address: "https://example.com/lists/ads.txt?format=hosts", Type: "block", Groups: []int64{0}, Enabled: true},
+		List{ID: 3, Address: "https://example.com/lists/allow.txt", Type: "allow", Groups: []int64{0}, Enabled: true},
+	)
+	return server
+}
+
+func TestAdlistsToggleResource_Metadata(t *testing.T) {
+	r := NewAdlistsToggleResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_adlists_toggle" {
+		t.Errorf("Expected TypeName to be 'pihole_adlists_toggle', got '%s'", resp.TypeName)
+	}
+}
+
+func TestAdlistsToggleResource_DisableAndEnable(t *testing.T) {
+	server := newAdlistsFake(t)
+
+	r := NewAdlistsToggleResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	createResp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"enabled":        tftypes.NewValue(tftypes.Bool, false),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+
+	enabled := server.listsEnabled()
+	if enabled["https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"] || enabled["https://example.com/lists/ads.txt?format=hosts"] {
+		t.Errorf("Expected all adlists to be disabled, got %v", enabled)
+	}
+	if !enabled["https://example.com/lists/allow.txt"] {
+		t.Error("Expected the allowlist to stay enabled")
+	}
+	if count := server.requestCount("POST /api/action/gravity"); count != 1 {
+		t.Errorf("Expected one gravity update, got %d", count)
+	}
+
+	var state types.Bool
+	createResp.State.GetAttribute(context.Background(), path.Root("enabled"), &state)
+	if state.ValueBool() {
+		t.Error("Expected the aggregate state to be disabled")
+	}
+
+	deleteResp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "adlists_toggle"),
+		"enabled":        tftypes.NewValue(tftypes.Bool, false),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", deleteResp.Diagnostics.Errors())
+	}
+
+	for address, listEnabled := range server.listsEnabled() {
+		if !listEnabled {
+			t.Errorf("Expected %s to be enabled again", address)
+		}
+	}
+	if count := server.requestCount("POST /api/action/gravity"); count != 1 {
+		t.Errorf("Expected no gravity update without update_gravity, got %d in total", count)
+	}
+}
+
+func TestAdlistsToggleResource_ReadReportsPartialDrift(t *testing.T) {
+	server := newAdlistsFake(t)
+	client := newTestClient(t, server.URL)
+
+	if err := client.SetAllListsEnabled(context.Background(), false); err != nil {
+		t.Fatalf("SetAllListsEnabled failed: %v", err)
+	}
+	// Someone re-enabled a single adlist in the web interface
+	if err := client.UpdateList(List{Address: "https://example.com/lists/ads.txt?format=hosts", Type: "block", Enabled: true}); err != nil {
+		t.Fatalf("UpdateList failed: %v", err)
+	}
+
+	r := NewAdlistsToggleResource()
+	testConfigureResource(t, r, client)
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "adlists_toggle"),
+		"enabled":        tftypes.NewValue(tftypes.Bool, false),
+		"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var state types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("enabled"), &state)
+	if !state.ValueBool() {
+		t.Error("Expected partially enabled adlists to show up as a difference to the configured false")
+	}
+}
+
+func TestAutoGravity_RunsOnceAfterParallelChanges(t *testing.T) {
+	testCases := []struct {
+		name        string
+		autoGravity bool
+		expected    int
+	}{
+		{"enabled", true, 1},
+		{"disabled", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newAdlistsFake(t)
+
+			client, err := NewPiholeClient(server.URL, "test-password", ClientConfig{
+				MaxConnections: 1,
+				RetryAttempts:  1,
+				RetryBackoffMs: 10,
+				AutoGravity:    tc.autoGravity,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create Pi-hole client: %v", err)
+			}
+			client.gravityDebounce = 200 * time.Millisecond
+
+			toggle := NewAdlistsToggleResource()
+			testConfigureResource(t, toggle, client)
+			domain := NewDomainResource()
+			testConfigureResource(t, domain, client)
+
+			// Terraform applies independent resources in parallel
+			var wg sync.WaitGroup
+			errs := make(chan string, 4)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp := testResourceCreate(t, toggle, map[string]tftypes.Value{
+					"enabled":        tftypes.NewValue(tftypes.Bool, false),
+					"update_gravity": tftypes.NewValue(tftypes.Bool, false),
+				})
+				if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() > 0 {
+					errs <- fmt.Sprint(resp.Diagnostics)
+				}
+			}()
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					resp := testResourceCreate(t, domain, map[string]tftypes.Value{
+						"domain":  tftypes.NewValue(tftypes.String, fmt.Sprintf("ads%d.example.com", i)),
+						"type":    tftypes.NewValue(tftypes.String, "deny"),
+						"kind":    tftypes.NewValue(tftypes.String, "exact"),
+						"comment": tftypes.NewValue(tftypes.String, ""),
+						"enabled": tftypes.NewValue(tftypes.Bool, true),
+					})
+					if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() > 0 {
+						errs <- fmt.Sprint(resp.Diagnostics)
+					}
+				}(i)
+			}
+			wg.Wait()
+			close(errs)
+
+			for diags := range errs {
+				t.Errorf("Unexpected diagnostics: %s", diags)
+			}
+			if count := server.requestCount("POST /api/action/gravity"); count != tc.expected {
+				t.Errorf("Expected %d gravity updates, got %d", tc.expected, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APISettingsResource{}
+
+func NewAPISettingsResource() resource.Resource {
+	return &APISettingsResource{}
+}
+
+type APISettingsResource struct {
+	client *PiholeClient
+}
+
+type APISettingsResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	MaxSessions           types.Int64  `tfsdk:"max_sessions"`
+	SessionTimeoutSeconds types.Int64  `tfsdk:"session_timeout_seconds"`
+}
+
+// apiSettingsKeys maps the resource attributes to their paths in the webserver section
+var apiSettingsKeys = map[string][]string{
+	"max_sessions":            {"api", "max_sessions"},
+	"session_timeout_seconds": {"session", "timeout"},
+}
+
+func (r *APISettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_settings"
+}
+
+func (r *APISettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the API session limits of Pi-hole (`webserver.api.max_sessions` and `webserver.session.timeout`). " +
+			"Every Terraform run opens an API session, so these settings decide how many runs fit until stale sessions expire. " +
+			"Deleting this resource resets Pi-hole to 16 sessions with a timeout of 1800 seconds.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "API settings identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_sessions": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of concurrent API sessions",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"session_timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Time in seconds after which an idle session expires",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *APISettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *APISettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data APISettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(apiSettingsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set API settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APISettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data APISettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APISettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data APISettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(apiSettingsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update API settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APISettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.apply(map[string]interface{}{
+		"max_sessions":            int64(16),
+		"session_timeout_seconds": int64(1800),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset API settings, got error: %s", err))
+		return
+	}
+}
+
+// apply writes the given values in a single request, keeping the rest of the webserver section unchanged
+func (r *APISettingsResource) apply(values map[string]interface{}) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		if err := setNestedConfigValue(webserverConfig, apiSettingsKeys[key], value); err != nil {
+			return err
+		}
+	}
+
+	return r.client.SetWebserverConfig(webserverConfig)
+}
+
+// readInto reconciles the model with the API settings currently active in Pi-hole
+func (r *APISettingsResource) readInto(data *APISettingsResourceModel) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]int64, len(apiSettingsKeys))
+	for key, keyPath := range apiSettingsKeys {
+		value, ok := lookupNestedConfigValue(webserverConfig, keyPath).(float64)
+		if !ok {
+			return fmt.Errorf("unexpected value for webserver.%s: %v", strings.Join(keyPath, "."), lookupNestedConfigValue(webserverConfig, keyPath))
+		}
+		values[key] = int64(value)
+	}
+
+	data.ID = types.StringValue("api_settings")
+	data.MaxSessions = types.Int64Value(values["max_sessions"])
+	data.SessionTimeoutSeconds = types.Int64Value(values["session_timeout_seconds"])
+
+	return nil
+}
+
+// apiSettingsValues builds the values to write from the planned model, keyed by attribute name
+func apiSettingsValues(data APISettingsResourceModel) map[string]interface{} {
+	values := make(map[string]interface{})
+	if !data.MaxSessions.IsNull() && !data.MaxSessions.IsUnknown() {
+		values["max_sessions"] = data.MaxSessions.ValueInt64()
+	}
+	if !data.SessionTimeoutSeconds.IsNull() && !data.SessionTimeoutSeconds.IsUnknown() {
+		values["session_timeout_seconds"] = data.SessionTimeoutSeconds.ValueInt64()
+	}
+	return values
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// apiSettingsFakeSections is a webserver section with the session settings next to unrelated ones
+func apiSettingsFakeSections(maxSessions, timeout int) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"webserver": {
+			"port":    "80o,443os",
+			"api":     map[string]interface{}{"app_sudo": true, "max_sessions": maxSessions},
+			"session": map[string]interface{}{"timeout": timeout, "restore": true},
+		},
+	}
+}
+
+func TestAPISettingsResource_Metadata(t *testing.T) {
+	r := NewAPISettingsResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_api_settings" {
+		t.Errorf("Expected TypeName to be 'pihole_api_settings', got '%s'", resp.TypeName)
+	}
+}
+
+func TestAPISettingsResource_Validation(t *testing.T) {
+	r := NewAPISettingsResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	for _, name := range []string{"max_sessions", "session_timeout_seconds"} {
+		attr := schemaResp.Schema.Attributes[name].(schema.Int64Attribute)
+		for _, tc := range []struct {
+			value     int64
+			expectErr bool
+		}{{1, false}, {300, false}, {0, true}, {-1, true}} {
+			req := validator.Int64Request{Path: path.Root(name), ConfigValue: types.Int64Value(tc.value)}
+			resp := &validator.Int64Response{}
+			for _, v := range attr.Validators {
+				v.ValidateInt64(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For %s = %d: expected error %v, got %v", name, tc.value, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		}
+	}
+}
+
+func TestAPISettingsResource_SetsBothInOnePut(t *testing.T) {
+	server := newFakePihole(apiSettingsFakeSections(16, 1800))
+	defer server.Close()
+
+	r := NewAPISettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"max_sessions":            tftypes.NewValue(tftypes.Number, 32),
+		"session_timeout_seconds": tftypes.NewValue(tftypes.Number, 300),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if writes := server.writeRequests(); fmt.Sprint(writes) != "[PUT /api/config/webserver]" {
+		t.Errorf("Expected both settings to be written in a single PUT, got %v", writes)
+	}
+
+	webserverConfig := server.section("webserver")
+	api := webserverConfig["api"].(map[string]interface{})
+	session := webserverConfig["session"].(map[string]interface{})
+	if api["max_sessions"] != float64(32) || session["timeout"] != float64(300) {
+		t.Errorf("Expected 32 sessions with a 300s timeout, got %v/%v", api["max_sessions"], session["timeout"])
+	}
+	// Unrelated webserver settings must survive the write of the whole section
+	if api["app_sudo"] != true || session["restore"] != true || webserverConfig["port"] != "80o,443os" {
+		t.Errorf("Expected unrelated webserver settings to be preserved, got %v", webserverConfig)
+	}
+
+	var maxSessions, timeout types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("max_sessions"), &maxSessions)
+	resp.State.GetAttribute(context.Background(), path.Root("session_timeout_seconds"), &timeout)
+	if maxSessions.ValueInt64() != 32 || timeout.ValueInt64() != 300 {
+		t.Errorf("Expected state 32/300, got %d/%d", maxSessions.ValueInt64(), timeout.ValueInt64())
+	}
+}
+
+func TestAPISettingsResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(apiSettingsFakeSections(32, 300))
+	defer server.Close()
+
+	r := NewAPISettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	server.setValue("webserver.session.timeout", 600)
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":                      tftypes.NewValue(tftypes.String, "api_settings"),
+		"max_sessions":            tftypes.NewValue(tftypes.Number, 32),
+		"session_timeout_seconds": tftypes.NewValue(tftypes.Number, 300),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var timeout types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("session_timeout_seconds"), &timeout)
+	if timeout.ValueInt64() != 600 {
+		t.Errorf("Expected state to reflect Pi-hole (600), got %d", timeout.ValueInt64())
+	}
+}
+
+func TestAPISettingsResource_DeleteResetsToDefaults(t *testing.T) {
+	server := newFakePihole(apiSettingsFakeSections(32, 300))
+	defer server.Close()
+
+	r := NewAPISettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":                      tftypes.NewValue(tftypes.String, "api_settings"),
+		"max_sessions":            tftypes.NewValue(tftypes.Number, 32),
+		"session_timeout_seconds": tftypes.NewValue(tftypes.Number, 300),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	webserverConfig := server.section("webserver")
+	if maxSessions := webserverConfig["api"].(map[string]interface{})["max_sessions"]; maxSessions != float64(16) {
+		t.Errorf("Expected max_sessions to be reset to 16, got %v", maxSessions)
+	}
+	if timeout := webserverConfig["session"].(map[string]interface{})["timeout"]; timeout != float64(1800) {
+		t.Errorf("Expected the session timeout to be reset to 1800, got %v", timeout)
+	}
+}
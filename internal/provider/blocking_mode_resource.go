@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlockingModeResource{}
+var _ resource.ResourceWithValidateConfig = &BlockingModeResource{}
+
+// blockingModes are the ways FTL can answer blocked queries
+var blockingModes = []string{"NULL", "IP_NODATA_AAAA", "IP", "NX", "NODATA"}
+
+func NewBlockingModeResource() resource.Resource {
+	return &BlockingModeResource{}
+}
+
+type BlockingModeResource struct {
+	client *PiholeClient
+}
+
+type BlockingModeResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Mode         types.String `tfsdk:"mode"`
+	BlockingIPv4 types.String `tfsdk:"blocking_ipv4"`
+	BlockingIPv6 types.String `tfsdk:"blocking_ipv6"`
+}
+
+func (r *BlockingModeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocking_mode"
+}
+
+func (r *BlockingModeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages how Pi-hole answers blocked queries (`dns.blocking.mode`) and, for the IP modes, which " +
+			"addresses it answers with (`dns.reply.blocking`). Deleting this resource resets Pi-hole to the `NULL` blocking mode.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Blocking mode identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "Blocking mode. One of `NULL` (unspecified address), `IP_NODATA_AAAA` (IP address for A, " +
+					"no data for AAAA queries), `IP` (IP address), `NX` (NXDOMAIN) or `NODATA` (no data).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(blockingModes...),
+				},
+			},
+			"blocking_ipv4": schema.StringAttribute{
+				MarkdownDescription: "IPv4 address blocked A queries are answered with in the `IP` and `IP_NODATA_AAAA` modes. " +
+					"When not set, Pi-hole answers with the address of the interface the query arrived on.",
+				Optional: true,
+			},
+			"blocking_ipv6": schema.StringAttribute{
+				MarkdownDescription: "IPv6 address blocked AAAA queries are answered with in the `IP` mode. " +
+					"When not set, Pi-hole answers with the address of the interface the query arrived on.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *BlockingModeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BlockingModeResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.BlockingIPv4.IsNull() && !data.BlockingIPv4.IsUnknown() {
+		if ip := net.ParseIP(data.BlockingIPv4.ValueString()); ip == nil || ip.To4() == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("blocking_ipv4"),
+				"Invalid Blocking Address",
+				fmt.Sprintf("'%s' is not a valid IPv4 address.", data.BlockingIPv4.ValueString()),
+			)
+		}
+	}
+	if !data.BlockingIPv6.IsNull() && !data.BlockingIPv6.IsUnknown() {
+		if ip := net.ParseIP(data.BlockingIPv6.ValueString()); ip == nil || ip.To4() != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("blocking_ipv6"),
+				"Invalid Blocking Address",
+				fmt.Sprintf("'%s' is not a valid IPv6 address.", data.BlockingIPv6.ValueString()),
+			)
+		}
+	}
+
+	if data.Mode.IsUnknown() {
+		return
+	}
+
+	// The addresses are only used by the IP modes, setting them elsewhere is most likely a mistake
+	mode := data.Mode.ValueString()
+	if !data.BlockingIPv4.IsNull() && mode != "IP" && mode != "IP_NODATA_AAAA" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocking_ipv4"),
+			"Blocking Address Not Used",
+			fmt.Sprintf("The '%s' blocking mode doesn't answer with an address. Use the 'IP' or 'IP_NODATA_AAAA' mode.", mode),
+		)
+	}
+	if !data.BlockingIPv6.IsNull() && mode != "IP" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocking_ipv6"),
+			"Blocking Address Not Used",
+			fmt.Sprintf("The '%s' blocking mode doesn't answer AAAA queries with an address. Use the 'IP' mode.", mode),
+		)
+	}
+}
+
+func (r *BlockingModeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BlockingModeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlockingModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", blockingModeValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set blocking mode, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blocking mode, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingModeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BlockingModeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blocking mode, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingModeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BlockingModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", blockingModeValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update blocking mode, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blocking mode, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingModeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.client.SetConfigSection("dns", blockingModeValues(BlockingModeResourceModel{
+		Mode:         types.StringValue("NULL"),
+		BlockingIPv4: types.StringNull(),
+		BlockingIPv6: types.StringNull(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset blocking mode, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the blocking mode currently active in Pi-hole
+func (r *BlockingModeResource) readInto(data *BlockingModeResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	modeValue := lookupNestedConfigValue(dnsConfig, []string{"blocking", "mode"})
+	mode, ok := modeValue.(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.blocking.mode: %v", modeValue)
+	}
+
+	data.ID = types.StringValue("blocking_mode")
+	data.Mode = types.StringValue(mode)
+	data.BlockingIPv4 = forcedBlockingAddress(dnsConfig, "force4", "IPv4")
+	data.BlockingIPv6 = forcedBlockingAddress(dnsConfig, "force6", "IPv6")
+
+	return nil
+}
+
+// forcedBlockingAddress returns the configured blocking address, or null if Pi-hole uses the interface address
+func forcedBlockingAddress(dnsConfig map[string]interface{}, forceKey, addressKey string) types.String {
+	forced, _ := lookupNestedConfigValue(dnsConfig, []string{"reply", "blocking", forceKey}).(bool)
+	address, _ := lookupNestedConfigValue(dnsConfig, []string{"reply", "blocking", addressKey}).(string)
+	if !forced || address == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(address)
+}
+
+// blockingModeValues builds the dns section payload from the planned model. Unset addresses switch
+// Pi-hole back to answering with the address of the receiving interface.
+func blockingModeValues(data BlockingModeResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"blocking": map[string]interface{}{
+			"mode": data.Mode.ValueString(),
+		},
+		"reply": map[string]interface{}{
+			"blocking": map[string]interface{}{
+				"force4": !data.BlockingIPv4.IsNull(),
+				"IPv4":   data.BlockingIPv4.ValueString(),
+				"force6": !data.BlockingIPv6.IsNull(),
+				"IPv6":   data.BlockingIPv6.ValueString(),
+			},
+		},
+	}
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// blockingModeFakeSections is a dns section with the blocking settings next to unrelated ones
+func blockingModeFakeSections(mode string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"port":     53,
+			"blocking": map[string]interface{}{"active": true, "mode": mode, "edns": "TEXT"},
+			"reply": map[string]interface{}{
+				"host":     map[string]interface{}{"force4": false, "IPv4": "", "force6": false, "IPv6": ""},
+				"blocking": map[string]interface{}{"force4": false, "IPv4": "", "force6": false, "IPv6": ""},
+			},
+		},
+	}
+}
+
+func TestBlockingModeResource_Schema(t *testing.T) {
+	r := NewBlockingModeResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	modeAttr, exists := schemaResp.Schema.Attributes["mode"]
+	if !exists {
+		t.Error("Schema should have 'mode' attribute")
+	} else if !modeAttr.IsRequired() {
+		t.Error("'mode' attribute should be required")
+	}
+
+	for _, name := range []string{"blocking_ipv4", "blocking_ipv6"} {
+		attr, exists := schemaResp.Schema.Attributes[name]
+		if !exists {
+			t.Errorf("Schema should have '%s' attribute", name)
+		} else if !attr.IsOptional() {
+			t.Errorf("'%s' attribute should be optional", name)
+		}
+	}
+}
+
+func TestBlockingModeResource_Metadata(t *testing.T) {
+	r := NewBlockingModeResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_blocking_mode" {
+		t.Errorf("Expected TypeName to be 'pihole_blocking_mode', got '%s'", resp.TypeName)
+	}
+}
+
+func TestBlockingModeResource_ModeValidation(t *testing.T) {
+	r := NewBlockingModeResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	modeAttr := schemaResp.Schema.Attributes["mode"].(schema.StringAttribute)
+
+	testCases := []struct {
+		mode      string
+		expectErr bool
+	}{
+		{"NULL", false},
+		{"IP_NODATA_AAAA", false},
+		{"IP", false},
+		{"NX", false},
+		{"NODATA", false},
+		{"NXDOMAIN", true},
+		{"null", true},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("mode"),
+				ConfigValue: types.StringValue(tc.mode),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range modeAttr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For mode '%s': expected error %v, got %v", tc.mode, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestBlockingModeResource_ValidateAddresses(t *testing.T) {
+	r := NewBlockingModeResource()
+
+	testCases := []struct {
+		name      string
+		config    map[string]tftypes.Value
+		expectErr bool
+	}{
+		{
+			name: "ip mode with addresses",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "IP"),
+				"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+				"blocking_ipv6": tftypes.NewValue(tftypes.String, "fd00::2"),
+			},
+		},
+		{
+			name: "ipv4 only mode with ipv4 address",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "IP_NODATA_AAAA"),
+				"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+			},
+		},
+		{
+			name: "nxdomain mode with address",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "NX"),
+				"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "ipv4 only mode with ipv6 address",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "IP_NODATA_AAAA"),
+				"blocking_ipv6": tftypes.NewValue(tftypes.String, "fd00::2"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid ipv4 address",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "IP"),
+				"blocking_ipv4": tftypes.NewValue(tftypes.String, "fd00::2"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid ipv6 address",
+			config: map[string]tftypes.Value{
+				"mode":          tftypes.NewValue(tftypes.String, "IP"),
+				"blocking_ipv6": tftypes.NewValue(tftypes.String, "not-an-address"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testResourceValidateConfig(t, r, tc.config)
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("Expected error %v, got %v", tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestBlockingModeResource_SetNXDOMAIN(t *testing.T) {
+	server := newFakePihole(blockingModeFakeSections("NULL"))
+	defer server.Close()
+
+	r := NewBlockingModeResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"mode": tftypes.NewValue(tftypes.String, "NX"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	blocking := dnsConfig["blocking"].(map[string]interface{})
+	if blocking["mode"] != "NX" {
+		t.Errorf("Expected dns.blocking.mode to be 'NX', got %v", blocking["mode"])
+	}
+	// Unrelated dns settings must be left untouched by the PATCH
+	if blocking["active"] != true || dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns settings to be preserved, got %v", dnsConfig)
+	}
+
+	var mode, ipv4 types.String
+	resp.State.GetAttribute(context.Background(), path.Root("mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("blocking_ipv4"), &ipv4)
+	if mode.ValueString() != "NX" || !ipv4.IsNull() {
+		t.Errorf("Expected state NX without address, got %s/%s", mode, ipv4)
+	}
+}
+
+func TestBlockingModeResource_SetIPModeWithAddress(t *testing.T) {
+	server := newFakePihole(blockingModeFakeSections("NULL"))
+	defer server.Close()
+
+	r := NewBlockingModeResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"mode":          tftypes.NewValue(tftypes.String, "IP"),
+		"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	reply := dnsConfig["reply"].(map[string]interface{})
+	blockingReply := reply["blocking"].(map[string]interface{})
+	if blockingReply["force4"] != true || blockingReply["IPv4"] != "192.168.1.2" {
+		t.Errorf("Expected blocked A queries to be answered with 192.168.1.2, got %v", blockingReply)
+	}
+	if blockingReply["force6"] != false {
+		t.Errorf("Expected AAAA queries to keep using the interface address, got %v", blockingReply)
+	}
+	// The host reply next to the blocking reply must not be touched
+	if _, exists := reply["host"]; !exists {
+		t.Error("Expected dns.reply.host to be preserved")
+	}
+
+	var mode, ipv4, ipv6 types.String
+	resp.State.GetAttribute(context.Background(), path.Root("mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("blocking_ipv4"), &ipv4)
+	resp.State.GetAttribute(context.Background(), path.Root("blocking_ipv6"), &ipv6)
+	if mode.ValueString() != "IP" || ipv4.ValueString() != "192.168.1.2" || !ipv6.IsNull() {
+		t.Errorf("Expected state IP/192.168.1.2/null, got %s/%s/%s", mode, ipv4, ipv6)
+	}
+}
+
+func TestBlockingModeResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(blockingModeFakeSections("IP"))
+	defer server.Close()
+
+	r := NewBlockingModeResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Someone switched to NODATA and stopped forcing the address in the web interface
+	server.setValue("dns.blocking.mode", "NODATA")
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "blocking_mode"),
+		"mode":          tftypes.NewValue(tftypes.String, "IP"),
+		"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var mode, ipv4 types.String
+	resp.State.GetAttribute(context.Background(), path.Root("mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("blocking_ipv4"), &ipv4)
+	if mode.ValueString() != "NODATA" || !ipv4.IsNull() {
+		t.Errorf("Expected state to reflect Pi-hole (NODATA without address), got %s/%s", mode, ipv4)
+	}
+}
+
+func TestBlockingModeResource_DeleteResetsToNull(t *testing.T) {
+	server := newFakePihole(blockingModeFakeSections("IP"))
+	defer server.Close()
+	server.setValue("dns.reply.blocking.force4", true)
+	server.setValue("dns.reply.blocking.IPv4", "192.168.1.2")
+
+	r := NewBlockingModeResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "blocking_mode"),
+		"mode":          tftypes.NewValue(tftypes.String, "IP"),
+		"blocking_ipv4": tftypes.NewValue(tftypes.String, "192.168.1.2"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	mode := dnsConfig["blocking"].(map[string]interface{})["mode"]
+	force4 := dnsConfig["reply"].(map[string]interface{})["blocking"].(map[string]interface{})["force4"]
+	if mode != "NULL" || force4 != false {
+		t.Errorf("Expected blocking mode to be reset to NULL without forced address, got %v/%v", mode, force4)
+	}
+}
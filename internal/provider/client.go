@@ -1,32 +1,171 @@
 package provider
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
+// snapshotWindow is how long a Snapshot of the records is reused. A refresh reads all resources within a few
+// seconds, while a later run starts a new provider process and reads them afresh.
+const snapshotWindow = 5 * time.Second
+
+// defaultRequestTimeout bounds regular API requests. Long-running actions use the deadline of their context instead.
+const defaultRequestTimeout = 60 * time.Second
+
+// batchProgressInterval is how many items a batch operation processes between two progress log lines
+const batchProgressInterval = 50
+
+// ErrConfigKeyNotFound is returned by GetConfig when Pi-hole does not know the requested configuration key,
+// e.g. because it was renamed or removed in a Pi-hole upgrade
+var ErrConfigKeyNotFound = errors.New("configuration key not found")
+
+// ErrSessionLimitExceeded is returned when Pi-hole refuses to open another API session because all
+// session seats (webserver.api.max_sessions) are taken
+var ErrSessionLimitExceeded = errors.New("Pi-hole API session limit reached")
+
+// ErrInvalidPassword is returned when Pi-hole rejects the configured password
+var ErrInvalidPassword = errors.New("Pi-hole rejected the password")
+
+// ErrAuthUnreachable is returned when the authentication request doesn't get an answer at all, e.g. because
+// the host is down, the port is wrong or the TLS handshake fails
+var ErrAuthUnreachable = errors.New("Pi-hole API is unreachable")
+
+// ErrEmptyResponse is returned when Pi-hole answers a request that should return data with an empty
+// body, which usually means the URL points at a proxy or web server rather than the Pi-hole API
+var ErrEmptyResponse = errors.New("Pi-hole returned an empty response")
+
+// ErrDNSRecordExists is returned by CreateDNSRecordIfAbsent when the domain already has a DNS record with another IP
+var ErrDNSRecordExists = errors.New("DNS record already exists")
+
+// RequestOption adjusts a single client call, e.g. to pace the calls of one resource differently
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	delay *time.Duration
+}
+
+// WithRequestDelay replaces the configured request delay for one call
+func WithRequestDelay(delayMs int64) RequestOption {
+	return func(o *requestOptions) {
+		delay := time.Duration(delayMs) * time.Millisecond
+		o.delay = &delay
+	}
+}
+
 type ClientConfig struct {
 	MaxConnections int
 	RequestDelayMs int
 	RetryAttempts  int
 	RetryBackoffMs int
 	InsecureTLS    bool
+
+	// RetryMaxBackoffMs caps the delay before a single retry, 0 disables the cap
+	RetryMaxBackoffMs int
+
+	// ReadRetryAttempts and WriteRetryAttempts replace RetryAttempts for reads (GET, HEAD) and for writes,
+	// nil keeps RetryAttempts
+	ReadRetryAttempts  *int
+	WriteRetryAttempts *int
+
+	// MinTLSVersion is the lowest TLS version accepted from Pi-hole (e.g. tls.VersionTLS13), 0 keeps Go's default
+	MinTLSVersion uint16
+
+	// DisableKeepAlives forces a new connection per request for networks that drop idle connections
+	DisableKeepAlives bool
+
+	// DefaultGroupIDs are assigned to new group-aware entries (e.g. domains) that don't set groups explicitly
+	DefaultGroupIDs []int64
+
+	// AutoGravity updates gravity once after a burst of blocklist changes, see ScheduleGravityUpdate
+	AutoGravity bool
+
+	// LocalSuffixes restricts the domains of new DNS and CNAME records to these suffixes, empty allows all
+	LocalSuffixes []string
+
+	// VerifyWrites reads DNS and CNAME records back after creating them and fails if Pi-hole didn't store them
+	VerifyWrites bool
 }
 
 type PiholeClient struct {
 	BaseURL    string
-	Password   string
 	HTTPClient *http.Client
-	SessionID  string
-	CSRFToken  string
 	Config     ClientConfig
+
+	// Password, SessionID and CSRFToken change when the web password is changed while other resources send
+	// requests through the shared client, so they are only accessed under sessionMu
+	sessionMu sync.RWMutex
+	Password  string
+	SessionID string
+	CSRFToken string
+
+	// sleepFunc implements the request delay and the retry backoff. Tests replace it to observe
+	// the delays without waiting.
+	sleepFunc func(time.Duration)
+
+	// logCtx carries the Terraform logger of the provider that created the client. The client outlives
+	// single requests, so it can't take the logger from the context of the resource operation.
+	logCtx context.Context
+
+	// requestTimeout bounds a regular API request from sending it until its response body is closed
+	requestTimeout time.Duration
+
+	// requestSlots holds one token per request in flight, so no more than MaxConnections requests run at once
+	// however many resources Terraform applies in parallel. Nil when MaxConnections doesn't set a limit.
+	requestSlots chan struct{}
+
+	// hostsMu serializes changes to dns.hosts, so a read-modify-write of the whole list can't
+	// overwrite a record another resource adds or removes at the same time
+	hostsMu sync.Mutex
+
+	// cnameMu does the same for dns.cnameRecords
+	cnameMu sync.Mutex
+
+	// listsMu serializes bulk changes to the adlists, so two toggles can't interleave their updates
+	listsMu sync.Mutex
+
+	// domainsMu does the same for bulk changes to a domain list
+	domainsMu sync.Mutex
+
+	// gravityGeneration counts the changes that asked for a gravity update, so only the last change
+	// of a burst runs it. gravityDebounce is how long a change waits for further ones.
+	gravityMu         sync.Mutex
+	gravityGeneration uint64
+	gravityDebounce   time.Duration
+
+	// dnsHostsKey caches the dns key Pi-hole stores local DNS records under, see dnsHostsKeys
+	dnsHostsKeyMu sync.Mutex
+	dnsHostsKey   string
+
+	// localDomain caches dns.domain, which rarely changes, for the lifetime of the client
+	localDomainMu     sync.Mutex
+	localDomain       string
+	localDomainLoaded bool
+
+	// snapshot caches the records of the last Snapshot for snapshotWindow. Every write through the client
+	// increments snapshotGeneration and drops it, so a fetch that overlaps a write is not cached.
+	snapshotMu         sync.Mutex
+	snapshot           *RecordSnapshot
+	snapshotGeneration uint64
+	snapshotWindow     time.Duration
+	snapshotFetch      singleflight.Group
 }
 
 type AuthRequest struct {
@@ -45,6 +184,26 @@ type AuthResponse struct {
 	Took float64 `json:"took"`
 }
 
+// APIErrorResponse is the error body Pi-hole returns for failed API requests
+type APIErrorResponse struct {
+	Error struct {
+		Key     string `json:"key"`
+		Message string `json:"message"`
+		Hint    string `json:"hint"`
+	} `json:"error"`
+}
+
+// processedResponse is the body of a successful write that Pi-hole may have applied only in part. Each
+// rejected item is listed with the reason, e.g. an invalid regex or URL.
+type processedResponse struct {
+	Processed struct {
+		Errors []struct {
+			Item  string `json:"item"`
+			Error string `json:"error"`
+		} `json:"errors"`
+	} `json:"processed"`
+}
+
 type DNSRecord struct {
 	Domain string `json:"domain"`
 	IP     string `json:"ip"`
@@ -53,6 +212,97 @@ type DNSRecord struct {
 type CNAMERecord struct {
 	Domain string `json:"domain"`
 	Target string `json:"target"`
+	// TTL is the optional third field of the record, 0 when the record has none
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// value formats the record the way Pi-hole stores it: domain,target[,ttl]
+func (r CNAMERecord) value() string {
+	if r.TTL > 0 {
+		return fmt.Sprintf("%s,%s,%d", r.Domain, r.Target, r.TTL)
+	}
+	return fmt.Sprintf("%s,%s", r.Domain, r.Target)
+}
+
+// Session is an API session as listed by /api/auth/sessions
+type Session struct {
+	ID             int64 `json:"id"`
+	CurrentSession bool  `json:"current_session"`
+	Valid          bool  `json:"valid"`
+	TLS            struct {
+		Login bool `json:"login"`
+		Mixed bool `json:"mixed"`
+	} `json:"tls"`
+	App        bool   `json:"app"`
+	LoginAt    int64  `json:"login_at"`
+	LastActive int64  `json:"last_active"`
+	ValidUntil int64  `json:"valid_until"`
+	RemoteAddr string `json:"remote_addr"`
+	UserAgent  string `json:"user_agent"`
+}
+
+// Domain is an entry of one of Pi-hole's domain lists (allow/deny, exact/regex)
+type Domain struct {
+	ID           int64   `json:"id"`
+	Domain       string  `json:"domain"`
+	Unicode      string  `json:"unicode"`
+	Type         string  `json:"type"`
+	Kind         string  `json:"kind"`
+	Comment      string  `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	Enabled      bool    `json:"enabled"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+// DomainRequest holds the writable fields of a domain list entry
+type DomainRequest struct {
+	Comment string  `json:"comment"`
+	Groups  []int64 `json:"groups,omitempty"`
+	Enabled bool    `json:"enabled"`
+}
+
+// List is a subscribed adlist (type block) or allowlist (type allow) that gravity downloads domains from
+type List struct {
+	ID           int64   `json:"id"`
+	Address      string  `json:"address"`
+	Type         string  `json:"type"`
+	Comment      string  `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	Enabled      bool    `json:"enabled"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+// Gateway is a default route Pi-hole detected, one per address family
+type Gateway struct {
+	Family    string   `json:"family"`
+	Interface string   `json:"interface"`
+	Address   string   `json:"address"`
+	Local     []string `json:"local"`
+}
+
+// GravityInfo is the state of the gravity database as reported in the stats summary. Pi-hole reports a
+// negative domain count when the gravity database is missing or can't be read.
+type GravityInfo struct {
+	DomainsBeingBlocked int64 `json:"domains_being_blocked"`
+	LastUpdate          int64 `json:"last_update"`
+}
+
+// HostInfo describes the machine Pi-hole runs on. Fields the platform or an older Pi-hole doesn't
+// report are left empty.
+type HostInfo struct {
+	Hostname string
+	Kernel   string
+	Model    string
+	// CPUCores is the number of processors available to Pi-hole
+	CPUCores int64
+	// MemoryTotal is the size of the RAM in KiB
+	MemoryTotal int64
+	// Uptime is the time since the machine booted in seconds
+	Uptime int64
+	// Docker is true if Pi-hole runs in its official Docker image
+	Docker bool
 }
 
 type ConfigSetting struct {
@@ -61,37 +311,116 @@ type ConfigSetting struct {
 }
 
 func NewPiholeClient(baseURL, password string, config ClientConfig) (*PiholeClient, error) {
-	client := &PiholeClient{
-		BaseURL:  baseURL,
-		Password: password,
-		Config:   config,
+	client := newPiholeClient(baseURL, password, config)
+
+	if err := client.authenticate(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// newPiholeClient creates a client without authenticating, so tests can replace sleepFunc first
+func newPiholeClient(baseURL, password string, config ClientConfig) *PiholeClient {
+	return &PiholeClient{
+		BaseURL:   baseURL,
+		Password:  password,
+		Config:    config,
+		sleepFunc: time.Sleep,
+		logCtx:    context.Background(),
+
+		gravityDebounce: autoGravityDebounce,
+		snapshotWindow:  snapshotWindow,
+		requestSlots:    newRequestSlots(config.MaxConnections),
+		requestTimeout:  defaultRequestTimeout,
+		// The client has no overall timeout: regular requests get a deadline of requestTimeout, while
+		// long-running actions such as gravity updates are bounded by the context of the caller
 		HTTPClient: &http.Client{
-			Timeout: 60 * time.Second,
+			// Compression is left to the transport, which asks for gzip and decompresses transparently
+			// as long as no Accept-Encoding header is set on the requests
 			Transport: &http.Transport{
-				TLSClientConfig:   &tls.Config{InsecureSkipVerify: config.InsecureTLS},
-				DisableKeepAlives: false,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.InsecureTLS,
+					MinVersion:         config.MinTLSVersion,
+				},
+				DisableKeepAlives: config.DisableKeepAlives,
 				IdleConnTimeout:   90 * time.Second,
 				MaxIdleConns:      10,
-				MaxConnsPerHost:   config.MaxConnections,
+				// MaxConnections is enforced by requestSlots rather than a connection limit, which would keep
+				// the connection of a running gravity update from being counted as free
 			},
 		},
 	}
+}
 
-	if err := client.authenticate(); err != nil {
-		return nil, err
+// newRequestSlots creates the semaphore for the given number of concurrent requests, none for 0 (unlimited)
+func newRequestSlots(maxConnections int) chan struct{} {
+	if maxConnections <= 0 {
+		return nil
 	}
+	return make(chan struct{}, maxConnections)
+}
 
-	return client, nil
+// acquireRequestSlot blocks until a request may start. The returned function frees the slot again and is
+// safe to call more than once.
+func (c *PiholeClient) acquireRequestSlot() func() {
+	if c.requestSlots == nil {
+		return func() {}
+	}
+
+	c.requestSlots <- struct{}{}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-c.requestSlots })
+	}
+}
+
+// releasingBody frees the request slot of a response and ends the deadline of its request once its body is
+// closed, as the connection stays in use until then
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
 }
 
 // Close cleans up the Pi-hole client session
 func (c *PiholeClient) Close() error {
 	// Pi-hole v6 sessions automatically expire, but we can clear our tokens
-	c.SessionID = ""
-	c.CSRFToken = ""
+	c.setSession("", "")
 	return nil
 }
 
+// session returns the session ID and CSRF token requests authenticate with
+func (c *PiholeClient) session() (string, string) {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+	return c.SessionID, c.CSRFToken
+}
+
+func (c *PiholeClient) setSession(sessionID, csrfToken string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.SessionID = sessionID
+	c.CSRFToken = csrfToken
+}
+
+// setSessionHeaders adds the session of the client to an API request
+func (c *PiholeClient) setSessionHeaders(req *http.Request) {
+	sessionID, csrfToken := c.session()
+	if sessionID != "" {
+		req.Header.Set("X-FTL-SID", sessionID)
+	}
+	if csrfToken != "" {
+		req.Header.Set("X-FTL-CSRF", csrfToken)
+	}
+}
+
 func (c *PiholeClient) authenticate() error {
 	return c.authenticateWithRetry(c.Config.RetryAttempts)
 }
@@ -102,12 +431,13 @@ func (c *PiholeClient) authenticateWithRetry(retries int) error {
 	for attempt := 0; attempt <= retries; attempt++ {
 		// Add delay between attempts (exponential backoff)
 		if attempt > 0 {
-			backoffDelay := time.Duration(attempt*attempt) * time.Duration(c.Config.RetryBackoffMs) * time.Millisecond
-			time.Sleep(backoffDelay)
+			c.sleepFunc(c.retryBackoff(attempt))
 		}
 
 		// Pi-hole v6 API authentication via /api/auth
+		c.sessionMu.RLock()
 		authReq := AuthRequest{Password: c.Password}
+		c.sessionMu.RUnlock()
 
 		jsonData, err := json.Marshal(authReq)
 		if err != nil {
@@ -115,26 +445,34 @@ func (c *PiholeClient) authenticateWithRetry(retries int) error {
 		}
 
 		authURL := fmt.Sprintf("%s/api/auth", c.BaseURL)
-		req, err := http.NewRequest("POST", authURL, bytes.NewBuffer(jsonData))
+		reqCtx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
+		req, err := http.NewRequestWithContext(reqCtx, "POST", authURL, bytes.NewBuffer(jsonData))
 		if err != nil {
+			cancel()
 			return fmt.Errorf("failed to create auth request: %w", err)
 		}
 
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("Content-Type", "application/json")
 
+		release := c.acquireRequestSlot()
 		resp, err := c.HTTPClient.Do(req)
 		if err != nil {
-			lastErr = err
+			release()
+			cancel()
+			lastErr = fmt.Errorf("%w: %w", ErrAuthUnreachable, err)
 			// Check if it's a connection error that might benefit from retry
 			if isRetryableError(err) && attempt < retries {
 				continue
 			}
-			return fmt.Errorf("failed to authenticate with Pi-hole: %w", err)
+			return fmt.Errorf("failed to authenticate with Pi-hole: %w", lastErr)
 		}
 		defer resp.Body.Close()
 
+		// The slot is freed right away, the deferred close only runs once all attempts are done
 		body, err := io.ReadAll(resp.Body)
+		release()
+		cancel()
 		if err != nil {
 			lastErr = err
 			if attempt < retries {
@@ -144,17 +482,26 @@ func (c *PiholeClient) authenticateWithRetry(retries int) error {
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("authentication failed with status: %d, body: %s", resp.StatusCode, string(body))
 			// Don't retry authentication failures (401, 429, etc.)
-			if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusTooManyRequests {
-				return lastErr
+			if isSessionLimitResponse(resp.StatusCode, body) {
+				return fmt.Errorf("%w (status: %d, body: %s)", ErrSessionLimitExceeded, resp.StatusCode, string(body))
 			}
+			if resp.StatusCode == http.StatusUnauthorized {
+				return fmt.Errorf("%w (status: %d, body: %s)", ErrInvalidPassword, resp.StatusCode, string(body))
+			}
+			lastErr = fmt.Errorf("authentication failed with status: %d, body: %s", resp.StatusCode, string(body))
 			if attempt < retries {
 				continue
 			}
 			return lastErr
 		}
 
+		// Retrying can't fix a misconfigured URL, so fail right away instead of retrying the unmarshal error
+		if len(bytes.TrimSpace(body)) == 0 {
+			return fmt.Errorf("%w: the authentication endpoint %s returned an empty response (status: %d), verify the url",
+				ErrEmptyResponse, authURL, resp.StatusCode)
+		}
+
 		var authResp AuthResponse
 		if err := json.Unmarshal(body, &authResp); err != nil {
 			lastErr = fmt.Errorf("failed to unmarshal auth response: %w, body: %s", err, string(body))
@@ -166,13 +513,11 @@ func (c *PiholeClient) authenticateWithRetry(retries int) error {
 
 		// Check if authentication was successful
 		if !authResp.Session.Valid {
-			lastErr = fmt.Errorf("authentication failed: %s", authResp.Session.Message)
 			// Don't retry invalid credentials
-			return lastErr
+			return fmt.Errorf("%w: %s", ErrInvalidPassword, authResp.Session.Message)
 		}
 
-		c.SessionID = authResp.Session.Sid
-		c.CSRFToken = authResp.Session.CSRF
+		c.setSession(authResp.Session.Sid, authResp.Session.CSRF)
 
 		return nil
 	}
@@ -180,18 +525,67 @@ func (c *PiholeClient) authenticateWithRetry(retries int) error {
 	return fmt.Errorf("authentication failed after %d attempts: %w", retries+1, lastErr)
 }
 
+// isSessionLimitResponse reports whether a failed /api/auth response means that all API session seats are taken.
+// Pi-hole answers with 429 and the "api_seats_exceeded" error key in that case.
+func isSessionLimitResponse(statusCode int, body []byte) bool {
+	var apiErr APIErrorResponse
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Key == "api_seats_exceeded" {
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests
+}
+
+// makeRequest sends a request, retrying transient failures only if the method is idempotent. Requests that
+// are safe to repeat despite their method opt in to retries with makeIdempotentRequest.
 func (c *PiholeClient) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequestWithRetry(method, endpoint, body, c.Config.RetryAttempts)
+	return c.makeRequestWithRetry(method, endpoint, body, c.retryAttempts(method), isIdempotentMethod(method))
+}
+
+// makeIdempotentRequest sends a request that can be repeated without further side effects, e.g. a PATCH
+// merging the same configuration again, so transient failures are retried regardless of the method
+func (c *PiholeClient) makeIdempotentRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+	return c.makeRequestWithRetry(method, endpoint, body, c.retryAttempts(method), true)
+}
+
+// retryAttempts returns how often a failed request with this method is retried. Reads can't change anything
+// in Pi-hole, so they may be retried more often than writes.
+func (c *PiholeClient) retryAttempts(method string) int {
+	switch method {
+	case "GET", "HEAD":
+		if c.Config.ReadRetryAttempts != nil {
+			return *c.Config.ReadRetryAttempts
+		}
+	default:
+		if c.Config.WriteRetryAttempts != nil {
+			return *c.Config.WriteRetryAttempts
+		}
+	}
+	return c.Config.RetryAttempts
+}
+
+// isIdempotentMethod reports whether repeating a request with this method has no further side effects.
+// POST triggers actions and creates items in Pi-hole, so a retry after a lost response could run it twice.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
 }
 
-func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interface{}, retries int) (*http.Response, error) {
+func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interface{}, retries int, idempotent bool) (*http.Response, error) {
 	var lastErr error
 
+	// A failed request may still have reached Pi-hole, so only idempotent requests are sent again
+	if !idempotent {
+		retries = 0
+	}
+
 	for attempt := 0; attempt <= retries; attempt++ {
 		// Add delay between attempts (exponential backoff)
 		if attempt > 0 {
-			backoffDelay := time.Duration(attempt*attempt) * time.Duration(c.Config.RetryBackoffMs) * time.Millisecond
-			time.Sleep(backoffDelay)
+			c.sleepFunc(c.retryBackoff(attempt))
 		}
 
 		var reqBody io.Reader
@@ -201,13 +595,23 @@ func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interf
 				return nil, fmt.Errorf("failed to marshal request body: %w", err)
 			}
 			reqBody = bytes.NewBuffer(jsonData)
+
+			if method != "GET" {
+				tflog.Trace(c.logCtx, "Pi-hole API request body", map[string]interface{}{
+					"method":   method,
+					"endpoint": endpoint,
+					"body":     redactRequestBody(jsonData),
+				})
+			}
 		}
 
 		// Build full URL for Pi-hole v6 API
 		fullURL := c.BaseURL + endpoint
 
-		req, err := http.NewRequest(method, fullURL, reqBody)
+		reqCtx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
+		req, err := http.NewRequestWithContext(reqCtx, method, fullURL, reqBody)
 		if err != nil {
+			cancel()
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
@@ -217,15 +621,20 @@ func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interf
 		}
 
 		// Add Pi-hole v6 API headers
-		if c.SessionID != "" {
-			req.Header.Set("X-FTL-SID", c.SessionID)
-		}
-		if c.CSRFToken != "" {
-			req.Header.Set("X-FTL-CSRF", c.CSRFToken)
-		}
+		c.setSessionHeaders(req)
 
+		release := c.acquireRequestSlot()
 		resp, err := c.HTTPClient.Do(req)
+		// Even a failed write may have reached Pi-hole, so any write makes the cached records stale
+		if method != "GET" {
+			c.invalidateSnapshot()
+		}
 		if err != nil {
+			release()
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("%s %s did not complete within %s: %w", method, endpoint, c.requestTimeout, err)
+			}
 			lastErr = err
 			// Check if it's a connection error that might benefit from retry
 			if isRetryableError(err) && attempt < retries {
@@ -233,6 +642,30 @@ func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interf
 			}
 			return nil, err
 		}
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() {
+			release()
+			cancel()
+		}}
+
+		// Reads always return a JSON document, an empty one points at a misconfigured URL rather than a
+		// transient problem and would otherwise surface as a confusing unmarshal error. Only the start of
+		// the body is buffered, so large responses can still be decoded as a stream.
+		if method == "GET" && resp.StatusCode == http.StatusOK {
+			reader := bufio.NewReader(resp.Body)
+			empty, err := isEmptyBody(reader)
+			if err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("failed to read response: %w", err)
+			}
+			if empty {
+				resp.Body.Close()
+				return nil, fmt.Errorf("%w: %s %s returned an empty response, verify the url", ErrEmptyResponse, method, endpoint)
+			}
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{reader, resp.Body}
+		}
 
 		// Success or non-retryable error
 		return resp, nil
@@ -241,45 +674,100 @@ func (c *PiholeClient) makeRequestWithRetry(method, endpoint string, body interf
 	return nil, fmt.Errorf("request failed after %d attempts: %w", retries+1, lastErr)
 }
 
-func isRetryableError(err error) bool {
-	errStr := err.Error()
-	return strings.Contains(errStr, "connection refused") ||
-		strings.Contains(errStr, "EOF") ||
-		strings.Contains(errStr, "timeout") ||
-		strings.Contains(errStr, "connection reset")
+// isEmptyBody reports whether the rest of the body is only whitespace. It consumes the leading
+// whitespace but leaves the first other byte in the reader.
+func isEmptyBody(reader *bufio.Reader) (bool, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return false, reader.UnreadByte()
+		}
+	}
 }
 
-func (c *PiholeClient) GetDNSRecords() ([]DNSRecord, error) {
-	resp, err := c.makeRequest("GET", "/api/config/dns/hosts", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DNS records: %w", err)
+// sensitiveRequestKeys are configuration keys whose values must never be logged
+var sensitiveRequestKeys = map[string]bool{
+	"password":    true,
+	"pwhash":      true,
+	"app_pwhash":  true,
+	"totp_secret": true,
+	"totp":        true,
+	"sid":         true,
+	"csrf":        true,
+}
+
+// redactRequestBody returns the JSON request body for logging, with the values of sensitive keys replaced
+// at any depth. Nested configuration merges can carry e.g. webserver.api.pwhash, so every level is checked.
+func redactRequestBody(jsonData []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(jsonData, &value); err != nil {
+		return "(unparsable body redacted)"
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	redacted, err := json.Marshal(redactValue(value))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read DNS records response: %w", err)
+		return "(unparsable body redacted)"
 	}
+	return string(redacted)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get DNS records, status: %d, body: %s", resp.StatusCode, string(body))
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if sensitiveRequestKeys[strings.ToLower(key)] {
+				v[key] = "***"
+			} else {
+				v[key] = redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
 	}
+	return value
+}
 
-	// Parse Pi-hole API v6 response structure
-	var apiResp struct {
-		Config struct {
-			DNS struct {
-				Hosts []string `json:"hosts"`
-			} `json:"dns"`
-		} `json:"config"`
+// retryBackoff returns the delay before the given retry attempt. It grows quadratically with the attempt
+// and is capped at RetryMaxBackoffMs so high retry counts don't result in minute-long waits.
+func (c *PiholeClient) retryBackoff(attempt int) time.Duration {
+	backoffDelay := time.Duration(attempt*attempt) * time.Duration(c.Config.RetryBackoffMs) * time.Millisecond
+
+	maxBackoff := time.Duration(c.Config.RetryMaxBackoffMs) * time.Millisecond
+	if maxBackoff > 0 && backoffDelay > maxBackoff {
+		return maxBackoff
 	}
+	return backoffDelay
+}
 
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal DNS records: %w, body: %s", err, string(body))
+func isRetryableError(err error) bool {
+	errStr := err.Error()
+	return strings.Contains(errStr, "connection refused") ||
+		strings.Contains(errStr, "EOF") ||
+		strings.Contains(errStr, "timeout") ||
+		strings.Contains(errStr, "connection reset")
+}
+
+func (c *PiholeClient) GetDNSRecords() ([]DNSRecord, error) {
+	hosts, err := c.getDNSHosts()
+	if err != nil {
+		return nil, err
 	}
 
+	return parseDNSRecords(hosts), nil
+}
+
+// parseDNSRecords parses "IP domain" lines, skipping malformed ones
+func parseDNSRecords(hosts []string) []DNSRecord {
 	var records []DNSRecord
-	for _, recordStr := range apiResp.Config.DNS.Hosts {
+	for _, recordStr := range hosts {
 		parts := strings.SplitN(recordStr, " ", 2)
 		if len(parts) == 2 {
 			records = append(records, DNSRecord{
@@ -289,12 +777,120 @@ func (c *PiholeClient) GetDNSRecords() ([]DNSRecord, error) {
 		}
 	}
 
-	return records, nil
+	return records
+}
+
+// dnsHostsKeys are the dns keys local DNS records are stored under, in the order they are probed.
+// Pi-hole v6 uses dns.hosts; some point releases expose the records as dns.customDNS instead.
+var dnsHostsKeys = []string{"hosts", "customDNS"}
+
+// getDNSHosts retrieves the local DNS records as raw "IP domain" lines. The first call detects which
+// of dnsHostsKeys this Pi-hole uses and caches it for the lifetime of the client.
+func (c *PiholeClient) getDNSHosts() ([]string, error) {
+	// The lock only guards the cached key, so reads of the records don't wait for each other. Parallel
+	// first calls may both detect the key, which yields the same result.
+	c.dnsHostsKeyMu.Lock()
+	detected := c.dnsHostsKey
+	c.dnsHostsKeyMu.Unlock()
+
+	keys := dnsHostsKeys
+	if detected != "" {
+		keys = []string{detected}
+	}
+
+	for i, key := range keys {
+		resp, err := c.makeRequest("GET", "/api/config/dns/"+key, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DNS records: %w", err)
+		}
+
+		// Not found while detecting means this Pi-hole stores the records under another key
+		if resp.StatusCode == http.StatusNotFound && detected == "" && i < len(keys)-1 {
+			resp.Body.Close()
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("%w: 'dns.%s' (status: %d, body: %s)", ErrConfigKeyNotFound, key, resp.StatusCode, string(body))
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to get DNS records, status: %d, body: %s", resp.StatusCode, string(body))
+		}
+
+		// Parse Pi-hole API v6 response structure. The list is decoded from the stream, as it can hold
+		// thousands of records.
+		var apiResp struct {
+			Config struct {
+				DNS map[string][]string `json:"dns"`
+			} `json:"config"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&apiResp)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode DNS records: %w", err)
+		}
+
+		if detected == "" {
+			c.dnsHostsKeyMu.Lock()
+			c.dnsHostsKey = key
+			c.dnsHostsKeyMu.Unlock()
+		}
+		return apiResp.Config.DNS[key], nil
+	}
+
+	return nil, fmt.Errorf("failed to get DNS records: none of the endpoints %v exist", dnsHostsKeys)
+}
+
+// detectedDNSHostsKey returns the dns key of the local DNS records detected by getDNSHosts, which
+// every write calls first. Before the detection it falls back to dns.hosts.
+func (c *PiholeClient) detectedDNSHostsKey() string {
+	c.dnsHostsKeyMu.Lock()
+	defer c.dnsHostsKeyMu.Unlock()
+
+	if c.dnsHostsKey == "" {
+		return dnsHostsKeys[0]
+	}
+	return c.dnsHostsKey
 }
 
 func (c *PiholeClient) CreateDNSRecord(domain, ip string) error {
+	c.hostsMu.Lock()
+	defer c.hostsMu.Unlock()
+
+	return c.createDNSRecord(domain, ip)
+}
+
+// CreateDNSRecordIfAbsent creates a DNS record unless the domain already points to another IP, in which case it
+// returns ErrDNSRecordExists instead of changing the record. The check holds hostsMu together with the write, so
+// a record created in parallel can't slip in between.
+func (c *PiholeClient) CreateDNSRecordIfAbsent(domain, ip string) error {
+	c.hostsMu.Lock()
+	defer c.hostsMu.Unlock()
+
+	records, err := c.GetDNSRecords()
+	if err != nil {
+		return fmt.Errorf("failed to get current DNS records: %w", err)
+	}
+
+	for _, record := range records {
+		if record.Domain == domain && record.IP != ip {
+			return fmt.Errorf("%w: '%s' points to %s", ErrDNSRecordExists, domain, record.IP)
+		}
+	}
+
+	return c.createDNSRecord(domain, ip)
+}
+
+// createDNSRecord implements CreateDNSRecord, the caller holds hostsMu
+func (c *PiholeClient) createDNSRecord(domain, ip string) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
 
 	// Check if record already exists
 	currentRecords, err := c.GetDNSRecords()
@@ -306,7 +902,7 @@ func (c *PiholeClient) CreateDNSRecord(domain, ip string) error {
 		if record.Domain == domain {
 			if record.IP != ip {
 				// Update existing record
-				return c.UpdateDNSRecord(domain, ip)
+				return c.updateDNSRecord(domain, ip)
 			}
 			// Record already exists with same IP, nothing to do
 			return nil
@@ -317,39 +913,136 @@ func (c *PiholeClient) CreateDNSRecord(domain, ip string) error {
 	// PUT /api/config/dns/hosts/192.168.0.22%20www.homelab.local
 	recordValue := fmt.Sprintf("%s %s", ip, domain)
 	encodedRecord := url.PathEscape(recordValue)
-	endpoint := fmt.Sprintf("/api/config/dns/hosts/%s", encodedRecord)
+	endpoint := fmt.Sprintf("/api/config/dns/%s/%s", c.detectedDNSHostsKey(), encodedRecord)
 
 	resp, err := c.makeRequest("PUT", endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DNS record: %w", err)
 	}
-	defer resp.Body.Close()
-
+	// The body holds a request slot, so it is closed before the record is read back
 	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
 
-	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
-		return nil
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create DNS record at %s, status: %d, body: %s", endpoint, resp.StatusCode, string(body))
+	}
+
+	if c.Config.VerifyWrites {
+		return c.verifyDNSRecord(domain, ip)
+	}
+
+	return nil
+}
+
+// verifyDNSRecord checks that Pi-hole stored a DNS record it accepted, as it may drop a write without an error
+func (c *PiholeClient) verifyDNSRecord(domain, ip string) error {
+	records, err := c.GetDNSRecords()
+	if err != nil {
+		return fmt.Errorf("failed to verify DNS record: %w", err)
+	}
+
+	for _, record := range records {
+		if record.Domain == domain && record.IP == ip {
+			return nil
+		}
 	}
 
-	return fmt.Errorf("failed to create DNS record at %s, status: %d, body: %s", endpoint, resp.StatusCode, string(body))
+	return fmt.Errorf("DNS record %s -> %s was accepted by Pi-hole but is missing when read back", domain, ip)
 }
 
+// UpdateDNSRecord changes the IP of a DNS record by deleting and re-creating it. The domain doesn't
+// resolve between the two requests, see SwapDNSRecordIP for the alternative.
 func (c *PiholeClient) UpdateDNSRecord(domain, ip string) error {
+	c.hostsMu.Lock()
+	defer c.hostsMu.Unlock()
+
+	return c.updateDNSRecord(domain, ip)
+}
+
+// updateDNSRecord implements UpdateDNSRecord, the caller holds hostsMu
+func (c *PiholeClient) updateDNSRecord(domain, ip string) error {
 	// First delete the old record, then create the new one
-	if err := c.DeleteDNSRecord(domain); err != nil {
+	if err := c.deleteDNSRecord(domain); err != nil {
 		return fmt.Errorf("failed to delete old DNS record: %w", err)
 	}
 
 	// Now create the new record
-	return c.CreateDNSRecord(domain, ip)
+	return c.createDNSRecord(domain, ip)
+}
+
+// SwapDNSRecordIP changes the IP of a DNS record from oldIP to newIP by replacing its line in dns.hosts
+// and writing the whole list in a single request, so the domain resolves to exactly one IP at all times.
+// If the domain has no record anymore it is created; if it points to an IP other than oldIP, it was
+// changed outside of Terraform and is left alone.
+func (c *PiholeClient) SwapDNSRecordIP(ctx context.Context, domain, oldIP, newIP string) error {
+	c.hostsMu.Lock()
+	defer c.hostsMu.Unlock()
+
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	hosts, err := c.getDNSHosts()
+	if err != nil {
+		return fmt.Errorf("failed to get current DNS records: %w", err)
+	}
+
+	index := -1
+	var otherIPs []string
+	for i, line := range hosts {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 || parts[1] != domain {
+			continue
+		}
+		if parts[0] == oldIP {
+			index = i
+		} else {
+			otherIPs = append(otherIPs, parts[0])
+		}
+	}
+
+	if index == -1 {
+		if len(otherIPs) > 0 {
+			return fmt.Errorf("failed to swap IP of DNS record %s: expected it to point to %s, but it points to %s",
+				domain, oldIP, strings.Join(otherIPs, ", "))
+		}
+		// The record vanished since it was read, so there is nothing to keep resolving
+		return c.createDNSRecord(domain, newIP)
+	}
+
+	updated := make([]string, len(hosts))
+	copy(updated, hosts)
+	updated[index] = fmt.Sprintf("%s %s", newIP, domain)
+
+	// Give up before writing if the operation was cancelled while the table was read
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to swap IP of DNS record %s: %w", domain, err)
+	}
+
+	if err := c.SetConfigSection("dns", map[string]interface{}{c.detectedDNSHostsKey(): updated}); err != nil {
+		return fmt.Errorf("failed to update DNS record: %w", err)
+	}
+
+	return nil
 }
 
 func (c *PiholeClient) DeleteDNSRecord(domain string) error {
+	c.hostsMu.Lock()
+	defer c.hostsMu.Unlock()
+
+	return c.deleteDNSRecord(domain)
+}
+
+// deleteDNSRecord implements DeleteDNSRecord, the caller holds hostsMu
+func (c *PiholeClient) deleteDNSRecord(domain string) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
 
 	// Get current records to find the exact record to delete
 	currentRecords, err := c.GetDNSRecords()
+	if errors.Is(err, ErrConfigKeyNotFound) {
+		// Without a list of local DNS records there is nothing left to delete
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get current DNS records: %w", err)
 	}
@@ -371,7 +1064,7 @@ func (c *PiholeClient) DeleteDNSRecord(domain string) error {
 	// Use DELETE method with URL-encoded record value in path
 	recordValue := fmt.Sprintf("%s %s", recordToDelete.IP, recordToDelete.Domain)
 	encodedRecord := url.PathEscape(recordValue)
-	endpoint := fmt.Sprintf("/api/config/dns/hosts/%s", encodedRecord)
+	endpoint := fmt.Sprintf("/api/config/dns/%s/%s", c.detectedDNSHostsKey(), encodedRecord)
 
 	resp, err := c.makeRequest("DELETE", endpoint, nil)
 	if err != nil {
@@ -381,26 +1074,68 @@ func (c *PiholeClient) DeleteDNSRecord(domain string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 
-	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotFound {
+		// Not found means the record was removed since it was listed
 		return nil
 	}
 
 	return fmt.Errorf("failed to delete DNS record, status: %d, body: %s", resp.StatusCode, string(body))
 }
 
+// GetCNAMERecords retrieves all CNAME records. Pi-hole versions or configurations without the
+// dns.cnameRecords key answer with 404; that is treated as having no CNAME records, so workflows
+// that only manage DNS records keep working.
 func (c *PiholeClient) GetCNAMERecords() ([]CNAMERecord, error) {
-	resp, err := c.makeRequest("GET", "/api/config/dns/cnameRecords", nil)
+	lines, err := c.getCNAMERecordLines()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get CNAME records: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CNAME records response: %w", err)
+	return parseCNAMERecords(lines), nil
+}
+
+// parseCNAMERecords parses "domain,target[,ttl]" lines, skipping malformed ones
+func parseCNAMERecords(lines []string) []CNAMERecord {
+	records := make([]CNAMERecord, 0, len(lines))
+	for _, recordStr := range lines {
+		parts := strings.SplitN(recordStr, ",", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		record := CNAMERecord{
+			Domain: parts[0],
+			Target: parts[1],
+		}
+		if len(parts) == 3 {
+			ttl, err := strconv.ParseInt(strings.TrimSpace(parts[2]), 10, 64)
+			if err != nil {
+				log.Printf("[WARN] Ignoring invalid TTL in CNAME record %q: %v", recordStr, err)
+			} else {
+				record.TTL = ttl
+			}
+		}
+		records = append(records, record)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	return records
+}
+
+// getCNAMERecordLines retrieves the CNAME records as raw "domain,target[,ttl]" lines
+func (c *PiholeClient) getCNAMERecordLines() ([]string, error) {
+	resp, err := c.makeRequest("GET", "/api/config/dns/cnameRecords", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CNAME records: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		log.Printf("[WARN] Pi-hole has no CNAME records endpoint (status: %d, body: %s), assuming there are no CNAME records", resp.StatusCode, string(body))
+		return []string{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get CNAME records, status: %d, body: %s", resp.StatusCode, string(body))
 	}
 
@@ -413,27 +1148,25 @@ func (c *PiholeClient) GetCNAMERecords() ([]CNAMERecord, error) {
 		} `json:"config"`
 	}
 
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal CNAME records: %w, body: %s", err, string(body))
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode CNAME records: %w", err)
 	}
 
-	var records []CNAMERecord
-	for _, recordStr := range apiResp.Config.DNS.CNAMERecords {
-		parts := strings.SplitN(recordStr, ",", 2)
-		if len(parts) == 2 {
-			records = append(records, CNAMERecord{
-				Domain: parts[0],
-				Target: parts[1],
-			})
-		}
-	}
+	return apiResp.Config.DNS.CNAMERecords, nil
+}
+
+// CreateCNAMERecord adds a CNAME record, a ttl of 0 leaves the TTL out of the record
+func (c *PiholeClient) CreateCNAMERecord(domain, target string, ttl int64) error {
+	c.cnameMu.Lock()
+	defer c.cnameMu.Unlock()
 
-	return records, nil
+	return c.createCNAMERecord(domain, target, ttl)
 }
 
-func (c *PiholeClient) CreateCNAMERecord(domain, target string) error {
+// createCNAMERecord implements CreateCNAMERecord, the caller holds cnameMu
+func (c *PiholeClient) createCNAMERecord(domain, target string, ttl int64) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
 
 	// Check if record already exists
 	currentRecords, err := c.GetCNAMERecords()
@@ -443,18 +1176,18 @@ func (c *PiholeClient) CreateCNAMERecord(domain, target string) error {
 
 	for _, record := range currentRecords {
 		if record.Domain == domain {
-			if record.Target != target {
+			if record.Target != target || record.TTL != ttl {
 				// Update existing record
-				return c.UpdateCNAMERecord(domain, target)
+				return c.updateCNAMERecord(domain, target, ttl)
 			}
-			// Record already exists with same target, nothing to do
+			// Record already exists with same target and TTL, nothing to do
 			return nil
 		}
 	}
 
 	// Pi-hole API v6 format: everything in URL with comma separator
-	// PUT /api/config/dns/cnameRecords/www.example.com,example.com
-	recordValue := fmt.Sprintf("%s,%s", domain, target)
+	// PUT /api/config/dns/cnameRecords/www.example.com,example.com[,300]
+	recordValue := CNAMERecord{Domain: domain, Target: target, TTL: ttl}.value()
 	encodedRecord := url.PathEscape(recordValue)
 	endpoint := fmt.Sprintf("/api/config/dns/cnameRecords/%s", encodedRecord)
 
@@ -462,30 +1195,126 @@ func (c *PiholeClient) CreateCNAMERecord(domain, target string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create CNAME record: %w", err)
 	}
-	defer resp.Body.Close()
-
+	// The body holds a request slot, so it is closed before the record is read back
 	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
 
-	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
-		return nil
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create CNAME record at %s, status: %d, body: %s", endpoint, resp.StatusCode, string(body))
+	}
+
+	if c.Config.VerifyWrites {
+		return c.verifyCNAMERecord(domain, target, ttl)
+	}
+
+	return nil
+}
+
+// verifyCNAMERecord checks that Pi-hole stored a CNAME record it accepted, as it may drop a write without an error
+func (c *PiholeClient) verifyCNAMERecord(domain, target string, ttl int64) error {
+	records, err := c.GetCNAMERecords()
+	if err != nil {
+		return fmt.Errorf("failed to verify CNAME record: %w", err)
+	}
+
+	for _, record := range records {
+		if record.Domain == domain && record.Target == target && record.TTL == ttl {
+			return nil
+		}
 	}
 
-	return fmt.Errorf("failed to create CNAME record at %s, status: %d, body: %s", endpoint, resp.StatusCode, string(body))
+	return fmt.Errorf("CNAME record %s -> %s was accepted by Pi-hole but is missing when read back", domain, target)
+}
+
+func (c *PiholeClient) UpdateCNAMERecord(domain, target string, ttl int64) error {
+	c.cnameMu.Lock()
+	defer c.cnameMu.Unlock()
+
+	return c.updateCNAMERecord(domain, target, ttl)
 }
 
-func (c *PiholeClient) UpdateCNAMERecord(domain, target string) error {
+// updateCNAMERecord implements UpdateCNAMERecord, the caller holds cnameMu
+func (c *PiholeClient) updateCNAMERecord(domain, target string, ttl int64) error {
 	// First delete the old record, then create the new one
-	if err := c.DeleteCNAMERecord(domain); err != nil {
+	if err := c.deleteCNAMERecord(domain); err != nil {
 		return fmt.Errorf("failed to delete old CNAME record: %w", err)
 	}
 
 	// Now create the new record
-	return c.CreateCNAMERecord(domain, target)
+	return c.createCNAMERecord(domain, target, ttl)
+}
+
+// CreateCNAMERecordsForTarget points all given domains to target, replacing any CNAME they have, and writes
+// the merged dns.cnameRecords list in a single request instead of one delete and create per domain.
+func (c *PiholeClient) CreateCNAMERecordsForTarget(ctx context.Context, target string, domains []string) error {
+	c.cnameMu.Lock()
+	defer c.cnameMu.Unlock()
+
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	lines, err := c.getCNAMERecordLines()
+	if err != nil {
+		return fmt.Errorf("failed to get current CNAME records: %w", err)
+	}
+
+	// wanted maps each domain to whether its record still has to be added
+	wanted := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		wanted[domain] = true
+	}
+
+	added := 0
+	merged := make([]string, 0, len(lines)+len(domains))
+	for _, line := range lines {
+		domain, _, _ := strings.Cut(line, ",")
+		pending, managed := wanted[domain]
+		if !managed {
+			merged = append(merged, line)
+			continue
+		}
+		// An identical record keeps its place, any other record of the domain is replaced
+		if pending && line == (CNAMERecord{Domain: domain, Target: target}).value() {
+			merged = append(merged, line)
+			wanted[domain] = false
+		}
+	}
+	for _, domain := range domains {
+		if wanted[domain] {
+			merged = append(merged, CNAMERecord{Domain: domain, Target: target}.value())
+			wanted[domain] = false
+			added++
+		}
+	}
+
+	if slices.Equal(merged, lines) {
+		return nil
+	}
+
+	// Give up before writing if the operation was cancelled while the records were read
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to create CNAME records for %s: %w", target, err)
+	}
+
+	if err := c.SetConfigSection("dns", map[string]interface{}{"cnameRecords": merged}); err != nil {
+		return fmt.Errorf("failed to create CNAME records for %s: %w", target, err)
+	}
+	newBatchProgress(ctx, "Created CNAME records for "+target, added).add(added)
+
+	return nil
 }
 
 func (c *PiholeClient) DeleteCNAMERecord(domain string) error {
+	c.cnameMu.Lock()
+	defer c.cnameMu.Unlock()
+
+	return c.deleteCNAMERecord(domain)
+}
+
+// deleteCNAMERecord implements DeleteCNAMERecord, the caller holds cnameMu
+func (c *PiholeClient) deleteCNAMERecord(domain string) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
 
 	// Get current records to find the exact record to delete
 	currentRecords, err := c.GetCNAMERecords()
@@ -508,7 +1337,7 @@ func (c *PiholeClient) DeleteCNAMERecord(domain string) error {
 	}
 
 	// Use DELETE method with URL-encoded record value in path
-	recordValue := fmt.Sprintf("%s,%s", recordToDelete.Domain, recordToDelete.Target)
+	recordValue := recordToDelete.value()
 	encodedRecord := url.PathEscape(recordValue)
 	endpoint := fmt.Sprintf("/api/config/dns/cnameRecords/%s", encodedRecord)
 
@@ -520,17 +1349,109 @@ func (c *PiholeClient) DeleteCNAMERecord(domain string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 
-	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotFound {
+		// Not found means the record was removed since it was listed
 		return nil
 	}
 
 	return fmt.Errorf("failed to delete CNAME record, status: %d, body: %s", resp.StatusCode, string(body))
 }
 
+// RecordSnapshot holds the local DNS and CNAME records as read at one point in time
+type RecordSnapshot struct {
+	DNSRecords   []DNSRecord
+	CNAMERecords []CNAMERecord
+
+	takenAt time.Time
+}
+
+// Snapshot returns the local DNS and CNAME records, fetching both lists in parallel. A refresh reads many
+// resources and data sources at once, so they share the snapshot for snapshotWindow instead of each fetching
+// the lists again. Concurrent calls wait for the same fetch, and any write through the client drops the snapshot.
+func (c *PiholeClient) Snapshot(ctx context.Context) (*RecordSnapshot, error) {
+	c.snapshotMu.Lock()
+	if c.snapshot != nil && time.Since(c.snapshot.takenAt) < c.snapshotWindow {
+		snapshot := c.snapshot
+		c.snapshotMu.Unlock()
+		return snapshot, nil
+	}
+	generation := c.snapshotGeneration
+	c.snapshotMu.Unlock()
+
+	// Callers after a write get a fetch of their own, as they use a newer generation
+	result := c.snapshotFetch.DoChan(strconv.FormatUint(generation, 10), func() (interface{}, error) {
+		snapshot, err := c.fetchSnapshot()
+		if err != nil {
+			return nil, err
+		}
+
+		c.snapshotMu.Lock()
+		if c.snapshotGeneration == generation {
+			c.snapshot = snapshot
+		}
+		c.snapshotMu.Unlock()
+
+		return snapshot, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*RecordSnapshot), nil
+	}
+}
+
+// fetchSnapshot reads the DNS and CNAME records in parallel
+func (c *PiholeClient) fetchSnapshot() (*RecordSnapshot, error) {
+	snapshot := &RecordSnapshot{takenAt: time.Now()}
+
+	var group errgroup.Group
+	group.Go(func() error {
+		records, err := c.GetDNSRecords()
+		snapshot.DNSRecords = records
+		return err
+	})
+	group.Go(func() error {
+		records, err := c.GetCNAMERecords()
+		snapshot.CNAMERecords = records
+		return err
+	})
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// invalidateSnapshot drops the cached snapshot after a write
+func (c *PiholeClient) invalidateSnapshot() {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+
+	c.snapshotGeneration++
+	c.snapshot = nil
+}
+
+// requestDelay is the delay before a call, the configured one unless an option overrides it
+func (c *PiholeClient) requestDelay(opts []RequestOption) time.Duration {
+	var options requestOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.delay != nil {
+		return *options.delay
+	}
+	return time.Duration(c.Config.RequestDelayMs) * time.Millisecond
+}
+
 // GetConfig retrieves a specific configuration setting from Pi-hole
-func (c *PiholeClient) GetConfig(configKey string) (*ConfigSetting, error) {
+func (c *PiholeClient) GetConfig(configKey string, opts ...RequestOption) (*ConfigSetting, error) {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(c.requestDelay(opts))
 
 	// Determine the appropriate endpoint based on the configuration key
 	var endpoint string
@@ -555,6 +1476,10 @@ func (c *PiholeClient) GetConfig(configKey string) (*ConfigSetting, error) {
 		return nil, fmt.Errorf("failed to read configuration response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: '%s'", ErrConfigKeyNotFound, configKey)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to get configuration '%s', status: %d, body: %s", configKey, resp.StatusCode, string(body))
 	}
@@ -577,7 +1502,7 @@ func (c *PiholeClient) GetConfig(configKey string) (*ConfigSetting, error) {
 			if val, exists := configMap[part]; exists {
 				currentValue = val
 			} else {
-				return nil, fmt.Errorf("configuration key '%s' not found", configKey)
+				return nil, fmt.Errorf("%w: '%s'", ErrConfigKeyNotFound, configKey)
 			}
 		} else {
 			return nil, fmt.Errorf("configuration structure is not as expected for key '%s'", configKey)
@@ -591,15 +1516,15 @@ func (c *PiholeClient) GetConfig(configKey string) (*ConfigSetting, error) {
 }
 
 // SetConfig updates a specific configuration setting in Pi-hole
-func (c *PiholeClient) SetConfig(configKey string, value interface{}) error {
+func (c *PiholeClient) SetConfig(configKey string, value interface{}, opts ...RequestOption) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(c.requestDelay(opts))
 
 	configParts := strings.Split(configKey, ".")
 
 	// Handle webserver configuration specially
 	if len(configParts) > 0 && configParts[0] == "webserver" {
-		return c.setWebserverConfigValue(configKey, value)
+		return c.setWebserverConfigValue(configKey, value, opts)
 	}
 
 	// For other configurations, use a more general approach
@@ -608,9 +1533,9 @@ func (c *PiholeClient) SetConfig(configKey string, value interface{}) error {
 }
 
 // setWebserverConfigValue updates a webserver configuration value
-func (c *PiholeClient) setWebserverConfigValue(configKey string, value interface{}) error {
+func (c *PiholeClient) setWebserverConfigValue(configKey string, value interface{}, opts []RequestOption) error {
 	// First get the current webserver configuration
-	currentConfig, err := c.GetWebserverConfig()
+	currentConfig, err := c.GetWebserverConfig(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to get current webserver config: %w", err)
 	}
@@ -630,8 +1555,27 @@ func (c *PiholeClient) setWebserverConfigValue(configKey string, value interface
 		updatedConfig[k] = v
 	}
 
-	// Navigate and update the nested structure
-	current := updatedConfig
+	// Keep the type Pi-hole stores for the key, which rejects e.g. the string "true" for a boolean
+	current := lookupNestedConfigValue(updatedConfig, keyParts)
+	if stringValue, ok := value.(string); ok {
+		if value, err = configValueFromString(stringValue, current); err != nil {
+			return fmt.Errorf("invalid value for '%s': %w", configKey, err)
+		}
+	} else if _, ok := current.(string); ok {
+		value = configValueToString(value)
+	}
+
+	if err := setNestedConfigValue(updatedConfig, keyParts, value); err != nil {
+		return err
+	}
+
+	// Update the webserver configuration
+	return c.SetWebserverConfig(updatedConfig, opts...)
+}
+
+// setNestedConfigValue sets a value in a nested configuration structure, creating intermediate objects as needed
+func setNestedConfigValue(config map[string]interface{}, keyParts []string, value interface{}) error {
+	current := config
 	for i, part := range keyParts {
 		if i == len(keyParts)-1 {
 			// Last part - set the value
@@ -649,14 +1593,13 @@ func (c *PiholeClient) setWebserverConfigValue(configKey string, value interface
 		}
 	}
 
-	// Update the webserver configuration
-	return c.SetWebserverConfig(updatedConfig)
+	return nil
 }
 
 // GetWebserverConfig retrieves the webserver configuration section
-func (c *PiholeClient) GetWebserverConfig() (map[string]interface{}, error) {
+func (c *PiholeClient) GetWebserverConfig(opts ...RequestOption) (map[string]interface{}, error) {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(c.requestDelay(opts))
 
 	resp, err := c.makeRequest("GET", "/api/config/webserver", nil)
 	if err != nil {
@@ -688,9 +1631,9 @@ func (c *PiholeClient) GetWebserverConfig() (map[string]interface{}, error) {
 }
 
 // SetWebserverConfig updates webserver configuration settings
-func (c *PiholeClient) SetWebserverConfig(config map[string]interface{}) error {
+func (c *PiholeClient) SetWebserverConfig(config map[string]interface{}, opts ...RequestOption) error {
 	// Add delay to prevent overwhelming the API
-	time.Sleep(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+	c.sleepFunc(c.requestDelay(opts))
 
 	resp, err := c.makeRequest("PUT", "/api/config/webserver", config)
 	if err != nil {
@@ -700,9 +1643,1010 @@ func (c *PiholeClient) SetWebserverConfig(config map[string]interface{}) error {
 
 	body, _ := io.ReadAll(resp.Body)
 
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to set webserver configuration, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	// The whole section is written at once, but Pi-hole may reject single keys in the body of a successful response
+	var apiResp processedResponse
+	if err := json.Unmarshal(body, &apiResp); err == nil && len(apiResp.Processed.Errors) > 0 {
+		rejected := make([]string, 0, len(apiResp.Processed.Errors))
+		for _, failed := range apiResp.Processed.Errors {
+			rejected = append(rejected, fmt.Sprintf("%s (%s)", failed.Item, failed.Error))
+		}
+		return fmt.Errorf("webserver configuration was only partially applied, rejected keys: %s", strings.Join(rejected, ", "))
+	}
+
+	return nil
+}
+
+// UpdateGravity rebuilds Pi-hole's gravity database from the configured lists. Pi-hole streams the
+// progress of the update and closes the response once it is done, so this waits for the end of the
+// stream. The context bounds the wait, as large lists can take minutes to process.
+func (c *PiholeClient) UpdateGravity(ctx context.Context) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/action/gravity", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create gravity update request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/plain")
+	c.setSessionHeaders(req)
+
+	// Unlike regular requests, a gravity update gets no deadline of requestTimeout, the context limits the wait
+	release := c.acquireRequestSlot()
+	defer release()
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("gravity update did not complete: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to start gravity update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Pi-hole streams the output of the update for minutes, so the slot is freed once the update started
+	// instead of holding up all other requests until it ends
+	release()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update gravity, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	// Pass the streamed progress on as it arrives, so a long update doesn't look like a hang
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			tflog.Debug(ctx, "Gravity update: "+line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("gravity update did not complete: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to read gravity update output: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleGravityUpdate updates gravity after a blocklist change when AutoGravity is enabled. Terraform doesn't tell
+// providers when an apply ends, so each change waits for autoGravityDebounce and only runs the update if no other
+// change arrived meanwhile. Changes applied in parallel therefore share a single update, while the last change
+// returns only once gravity is up to date.
+func (c *PiholeClient) ScheduleGravityUpdate(ctx context.Context) error {
+	if !c.Config.AutoGravity {
+		return nil
+	}
+
+	c.gravityMu.Lock()
+	c.gravityGeneration++
+	generation := c.gravityGeneration
+	c.gravityMu.Unlock()
+
+	c.sleepFunc(c.gravityDebounce)
+
+	c.gravityMu.Lock()
+	superseded := c.gravityGeneration != generation
+	c.gravityMu.Unlock()
+
+	// A later change runs the update and covers this one as well
+	if superseded {
+		return nil
+	}
+
+	gravityCtx, cancel := context.WithTimeout(ctx, defaultGravityTimeoutSeconds*time.Second)
+	defer cancel()
+
+	return c.UpdateGravity(gravityCtx)
+}
+
+// RestartDNS restarts FTL's DNS resolver, so settings that are only read when it starts take effect
+func (c *PiholeClient) RestartDNS(opts ...RequestOption) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(c.requestDelay(opts))
+
+	resp, err := c.makeRequest("POST", "/api/action/restartdns", nil)
+	if err != nil {
+		return fmt.Errorf("failed to restart DNS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to restart DNS, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetSessions lists the API sessions currently known to Pi-hole, including the client's own
+func (c *PiholeClient) GetSessions() ([]Session, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", "/api/auth/sessions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get sessions, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Sessions []Session `json:"sessions"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+
+	return apiResp.Sessions, nil
+}
+
+// DeleteSession revokes the API session with the given ID
+func (c *PiholeClient) DeleteSession(id int64) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("DELETE", fmt.Sprintf("/api/auth/session/%d", id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete session %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotFound {
+		// Not found means the session has already expired or was revoked
+		return nil
+	}
+
+	return fmt.Errorf("failed to delete session %d, status: %d, body: %s", id, resp.StatusCode, string(body))
+}
+
+// GetConfigSection retrieves a top-level configuration section (e.g. "dns")
+func (c *PiholeClient) GetConfigSection(section string, opts ...RequestOption) (map[string]interface{}, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(c.requestDelay(opts))
+
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/config/%s", section), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s configuration: %w", section, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s configuration response: %w", section, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s configuration, status: %d, body: %s", section, resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Config map[string]map[string]interface{} `json:"config"`
+	}
+
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s configuration: %w, body: %s", section, err, string(body))
+	}
+
+	sectionConfig, ok := apiResp.Config[section]
+	if !ok {
+		return nil, fmt.Errorf("configuration section '%s' not found in response", section)
+	}
+
+	return sectionConfig, nil
+}
+
+// SetConfigSection updates settings within a top-level configuration section.
+// Pi-hole merges the PATCH body into its configuration, so only the given keys change.
+func (c *PiholeClient) SetConfigSection(section string, values map[string]interface{}, opts ...RequestOption) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(c.requestDelay(opts))
+
+	payload := map[string]interface{}{
+		"config": map[string]interface{}{
+			section: values,
+		},
+	}
+
+	// Merging the same values again leaves the configuration unchanged, so the PATCH is safe to retry
+	resp, err := c.makeIdempotentRequest("PATCH", "/api/config", payload)
+	if err != nil {
+		return fmt.Errorf("failed to set %s configuration: %w", section, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
 	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
 
-	return fmt.Errorf("failed to set webserver configuration, status: %d, body: %s", resp.StatusCode, string(body))
+	return fmt.Errorf("failed to set %s configuration, status: %d, body: %s", section, resp.StatusCode, string(body))
+}
+
+// SetWebPassword sets the password of the web interface and the API (webserver.api.password).
+// Pi-hole hashes the plaintext itself and ends all sessions, so the client logs in again with the new password.
+func (c *PiholeClient) SetWebPassword(password string) error {
+	err := c.SetConfigSection("webserver", map[string]interface{}{
+		"api": map[string]interface{}{"password": password},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.sessionMu.Lock()
+	oldPassword := c.Password
+	c.Password = password
+	c.sessionMu.Unlock()
+
+	// Later provider configurations with the new password reuse this client instead of logging in again
+	rekeyCachedClient(c, oldPassword, password)
+
+	if err := c.authenticate(); err != nil {
+		return fmt.Errorf("failed to authenticate with the new password: %w", err)
+	}
+
+	return nil
+}
+
+// GetLocalDomain returns the local domain suffix configured in Pi-hole (dns.domain).
+// The value is fetched once and cached for the lifetime of the client.
+func (c *PiholeClient) GetLocalDomain() (string, error) {
+	c.localDomainMu.Lock()
+	defer c.localDomainMu.Unlock()
+
+	if c.localDomainLoaded {
+		return c.localDomain, nil
+	}
+
+	dnsConfig, err := c.GetConfigSection("dns")
+	if err != nil {
+		return "", fmt.Errorf("failed to get local domain: %w", err)
+	}
+
+	// Older v6 releases store the domain as a plain string, newer ones as an object with a name
+	switch domain := dnsConfig["domain"].(type) {
+	case string:
+		c.localDomain = domain
+	case map[string]interface{}:
+		c.localDomain, _ = domain["name"].(string)
+	}
+	c.localDomainLoaded = true
+
+	return c.localDomain, nil
+}
+
+// qualifyDomain appends the local domain suffix to unqualified (single-label) host names
+func qualifyDomain(domain, localDomain string) string {
+	if localDomain == "" || strings.Contains(domain, ".") {
+		return domain
+	}
+	return domain + "." + localDomain
+}
+
+// hasLocalSuffix reports whether the domain is one of the suffixes or below one of them. Unqualified host names are
+// always local, as Pi-hole qualifies them with its own local domain.
+func hasLocalSuffix(domain string, suffixes []string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if !strings.Contains(domain, ".") {
+		return true
+	}
+
+	for _, suffix := range suffixes {
+		suffix = strings.ToLower(strings.Trim(suffix, "."))
+		if domain == suffix || strings.HasSuffix(domain, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// domainEndpoint builds the /api/domains path for a list and optionally a single entry.
+// Domains, and regex patterns in particular, are escaped as a single path segment so
+// characters like '/', '?' or '#' are sent verbatim instead of changing the URL.
+func domainEndpoint(domainType, kind, domain string) string {
+	endpoint := fmt.Sprintf("/api/domains/%s/%s", domainType, kind)
+	if domain != "" {
+		endpoint += "/" + url.PathEscape(domain)
+	}
+	return endpoint
+}
+
+// GetDomains retrieves all entries of a domain list, e.g. the regex allow list
+func (c *PiholeClient) GetDomains(domainType, kind string) ([]Domain, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", domainEndpoint(domainType, kind, ""), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s domains: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get %s %s domains, status: %d, body: %s", domainType, kind, resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Domains []Domain `json:"domains"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode domains: %w", err)
+	}
+
+	return apiResp.Domains, nil
+}
+
+// GetDomain retrieves a single domain list entry. It returns nil if the entry does not exist.
+func (c *PiholeClient) GetDomain(domainType, kind, domain string) (*Domain, error) {
+	domains, err := c.GetDomains(domainType, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is filtered locally because Pi-hole matches the path segment of regex entries
+	// as a pattern rather than comparing it literally
+	for _, d := range domains {
+		if d.Domain == domain {
+			return &d, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CreateDomain adds a domain or regex pattern to a domain list
+func (c *PiholeClient) CreateDomain(domainType, kind, domain string, request DomainRequest) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	// The domain is sent in the body, so it needs no URL escaping
+	payload := struct {
+		Domain string `json:"domain"`
+		DomainRequest
+	}{
+		Domain:        domain,
+		DomainRequest: request,
+	}
+
+	resp, err := c.makeRequest("POST", domainEndpoint(domainType, kind, ""), payload)
+	if err != nil {
+		return fmt.Errorf("failed to create %s %s domain: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create %s %s domain '%s', status: %d, body: %s", domainType, kind, domain, resp.StatusCode, string(body))
+	}
+
+	// Pi-hole reports per-item failures (e.g. an invalid regex) in the body of a successful response
+	var apiResp processedResponse
+
+	if err := json.Unmarshal(body, &apiResp); err == nil && len(apiResp.Processed.Errors) > 0 {
+		return fmt.Errorf("failed to create %s %s domain '%s': %s", domainType, kind, domain, apiResp.Processed.Errors[0].Error)
+	}
+
+	return nil
+}
+
+// UpdateDomain changes the comment, groups or enabled state of a domain list entry
+func (c *PiholeClient) UpdateDomain(domainType, kind, domain string, request DomainRequest) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	payload := struct {
+		Type string `json:"type"`
+		Kind string `json:"kind"`
+		DomainRequest
+	}{
+		Type:          domainType,
+		Kind:          kind,
+		DomainRequest: request,
+	}
+
+	resp, err := c.makeRequest("PUT", domainEndpoint(domainType, kind, domain), payload)
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s domain: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+
+	return fmt.Errorf("failed to update %s %s domain '%s', status: %d, body: %s", domainType, kind, domain, resp.StatusCode, string(body))
+}
+
+// DeleteDomain removes a domain or regex pattern from a domain list
+func (c *PiholeClient) DeleteDomain(domainType, kind, domain string) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("DELETE", domainEndpoint(domainType, kind, domain), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s domain: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotFound {
+		// Not found means it has already been deleted
+		return nil
+	}
+
+	return fmt.Errorf("failed to delete %s %s domain '%s', status: %d, body: %s", domainType, kind, domain, resp.StatusCode, string(body))
+}
+
+// ApplyDomains makes the desired entries exist in a domain list and removes the entries in removed that still exist.
+// New entries are added with one request, with the comment and enabled flag of request, and removed entries with a
+// single batch delete. Entries that already exist are left as they are. It reports whether anything changed.
+func (c *PiholeClient) ApplyDomains(ctx context.Context, domainType, kind string, desired, removed []string, request DomainRequest) (bool, error) {
+	c.domainsMu.Lock()
+	defer c.domainsMu.Unlock()
+
+	domains, err := c.GetDomains(domainType, kind)
+	if err != nil {
+		return false, err
+	}
+
+	current := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		current[domain.Domain] = true
+	}
+
+	var created []string
+	for _, domain := range desired {
+		if !current[domain] {
+			created = append(created, domain)
+		}
+	}
+	var deleted []string
+	for _, domain := range removed {
+		if current[domain] && !slices.Contains(desired, domain) {
+			deleted = append(deleted, domain)
+		}
+	}
+
+	changed := false
+	if len(created) > 0 {
+		if err := ctx.Err(); err != nil {
+			return changed, fmt.Errorf("stopped before creating %s %s domains: %w", domainType, kind, err)
+		}
+		if err := c.createDomains(ctx, domainType, kind, created, request); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	if len(deleted) > 0 {
+		if err := ctx.Err(); err != nil {
+			return changed, fmt.Errorf("stopped before deleting %s %s domains: %w", domainType, kind, err)
+		}
+		if err := c.deleteDomains(ctx, domainType, kind, deleted); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// createDomains adds several entries to a domain list with a single request
+func (c *PiholeClient) createDomains(ctx context.Context, domainType, kind string, domains []string, request DomainRequest) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	payload := struct {
+		Domain []string `json:"domain"`
+		DomainRequest
+	}{
+		Domain:        domains,
+		DomainRequest: request,
+	}
+
+	resp, err := c.makeRequest("POST", domainEndpoint(domainType, kind, ""), payload)
+	if err != nil {
+		return fmt.Errorf("failed to create %s %s domains: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create %s %s domains, status: %d, body: %s", domainType, kind, resp.StatusCode, string(body))
+	}
+
+	// Pi-hole reports per-item failures (e.g. an invalid regex) in the body of a successful response
+	var apiResp processedResponse
+
+	if err := json.Unmarshal(body, &apiResp); err == nil && len(apiResp.Processed.Errors) > 0 {
+		failed := apiResp.Processed.Errors[0]
+		return fmt.Errorf("failed to create %s %s domain '%s': %s", domainType, kind, failed.Item, failed.Error)
+	}
+
+	newBatchProgress(ctx, fmt.Sprintf("Created %s %s domains", domainType, kind), len(domains)).add(len(domains))
+	return nil
+}
+
+// deleteDomains removes entries from a domain list with a single batch delete
+func (c *PiholeClient) deleteDomains(ctx context.Context, domainType, kind string, domains []string) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	type batchItem struct {
+		Item string `json:"item"`
+		Type string `json:"type"`
+		Kind string `json:"kind"`
+	}
+
+	payload := make([]batchItem, 0, len(domains))
+	for _, domain := range domains {
+		payload = append(payload, batchItem{Item: domain, Type: domainType, Kind: kind})
+	}
+
+	resp, err := c.makeRequest("POST", "/api/domains:batchDelete", payload)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s domains: %w", domainType, kind, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		newBatchProgress(ctx, fmt.Sprintf("Deleted %s %s domains", domainType, kind), len(domains)).add(len(domains))
+		return nil
+	}
+
+	return fmt.Errorf("failed to delete %s %s domains, status: %d, body: %s", domainType, kind, resp.StatusCode, string(body))
+}
+
+// GetLists retrieves all subscribed lists, adlists as well as allowlists
+func (c *PiholeClient) GetLists() ([]List, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", "/api/lists", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get lists, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Lists []List `json:"lists"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode lists: %w", err)
+	}
+
+	return apiResp.Lists, nil
+}
+
+// UpdateList writes the comment, groups and enabled flag of a subscribed list. The address is
+// escaped as a single path segment, as list URLs contain '/' and often '?'.
+func (c *PiholeClient) UpdateList(list List) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	payload := struct {
+		Type    string  `json:"type"`
+		Comment string  `json:"comment"`
+		Groups  []int64 `json:"groups,omitempty"`
+		Enabled bool    `json:"enabled"`
+	}{
+		Type:    list.Type,
+		Comment: list.Comment,
+		Groups:  list.Groups,
+		Enabled: list.Enabled,
+	}
+
+	endpoint := fmt.Sprintf("/api/lists/%s?type=%s", url.PathEscape(list.Address), url.QueryEscape(list.Type))
+	resp, err := c.makeRequest("PUT", endpoint, payload)
+	if err != nil {
+		return fmt.Errorf("failed to update list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+
+	return fmt.Errorf("failed to update list '%s', status: %d, body: %s", list.Address, resp.StatusCode, string(body))
+}
+
+// batchProgress logs how far a batch operation got, so users following TF_LOG see long applies advance
+type batchProgress struct {
+	ctx       context.Context
+	operation string
+	total     int
+	done      int
+	logged    int
+}
+
+func newBatchProgress(ctx context.Context, operation string, total int) *batchProgress {
+	return &batchProgress{ctx: ctx, operation: operation, total: total}
+}
+
+// add records n more processed items and logs a line every batchProgressInterval items and once all are done
+func (p *batchProgress) add(n int) {
+	p.done += n
+	if n == 0 || (p.done < p.total && p.done-p.logged < batchProgressInterval) {
+		return
+	}
+
+	p.logged = p.done
+	tflog.Info(p.ctx, fmt.Sprintf("%s %d/%d", p.operation, p.done, p.total), map[string]interface{}{
+		"done":  p.done,
+		"total": p.total,
+	})
+}
+
+// listKey identifies a list, as Pi-hole allows the same address once as an adlist and once as an allowlist
+func listKey(list List) string {
+	return list.Type + " " + list.Address
+}
+
+// ApplyLists makes the given lists exist with their comment and enabled flag, and removes the lists in removed
+// that still exist. New lists are added with one request per type, comment and enabled flag, and removed lists
+// with a single batch delete. It reports whether anything changed, as only then gravity needs an update.
+func (c *PiholeClient) ApplyLists(ctx context.Context, desired, removed []List) (bool, error) {
+	c.listsMu.Lock()
+	defer c.listsMu.Unlock()
+
+	lists, err := c.GetLists()
+	if err != nil {
+		return false, err
+	}
+
+	current := make(map[string]List, len(lists))
+	for _, list := range lists {
+		current[listKey(list)] = list
+	}
+
+	var created, updated []List
+	for _, list := range desired {
+		existing, exists := current[listKey(list)]
+		if !exists {
+			created = append(created, list)
+			continue
+		}
+		if existing.Comment == list.Comment && existing.Enabled == list.Enabled {
+			continue
+		}
+
+		// Groups are not managed here, so the list keeps the ones it has
+		existing.Comment = list.Comment
+		existing.Enabled = list.Enabled
+		updated = append(updated, existing)
+	}
+
+	changed := false
+	progress := newBatchProgress(ctx, "Updated lists", len(updated))
+	for _, list := range updated {
+		if err := ctx.Err(); err != nil {
+			return changed, fmt.Errorf("stopped before updating list '%s': %w", list.Address, err)
+		}
+
+		if err := c.UpdateList(list); err != nil {
+			return changed, err
+		}
+		changed = true
+		progress.add(1)
+	}
+
+	if len(created) > 0 {
+		if err := c.createLists(ctx, created); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	var deleted []List
+	for _, list := range removed {
+		if _, exists := current[listKey(list)]; exists {
+			deleted = append(deleted, list)
+		}
+	}
+	if len(deleted) > 0 {
+		if err := c.deleteLists(ctx, deleted); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// createLists adds lists, sending all addresses that share the type, comment and enabled flag in one request
+func (c *PiholeClient) createLists(ctx context.Context, lists []List) error {
+	type batchKey struct {
+		listType string
+		comment  string
+		enabled  bool
+	}
+
+	var order []batchKey
+	batches := make(map[batchKey][]string)
+	for _, list := range lists {
+		key := batchKey{listType: list.Type, comment: list.Comment, enabled: list.Enabled}
+		if _, exists := batches[key]; !exists {
+			order = append(order, key)
+		}
+		batches[key] = append(batches[key], list.Address)
+	}
+
+	progress := newBatchProgress(ctx, "Created lists", len(lists))
+	for _, key := range order {
+		// Add delay to prevent overwhelming the API
+		c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+		payload := struct {
+			Address []string `json:"address"`
+			Comment string   `json:"comment"`
+			Enabled bool     `json:"enabled"`
+		}{
+			Address: batches[key],
+			Comment: key.comment,
+			Enabled: key.enabled,
+		}
+
+		resp, err := c.makeRequest("POST", "/api/lists?type="+url.QueryEscape(key.listType), payload)
+		if err != nil {
+			return fmt.Errorf("failed to create lists: %w", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+			return fmt.Errorf("failed to create lists %v, status: %d, body: %s", batches[key], resp.StatusCode, string(body))
+		}
+
+		// Pi-hole reports per-item failures (e.g. an invalid URL) in the body of a successful response
+		var apiResp processedResponse
+
+		if err := json.Unmarshal(body, &apiResp); err == nil && len(apiResp.Processed.Errors) > 0 {
+			failed := apiResp.Processed.Errors[0]
+			return fmt.Errorf("failed to create list '%s': %s", failed.Item, failed.Error)
+		}
+		progress.add(len(batches[key]))
+	}
+
+	return nil
+}
+
+// deleteLists removes lists with a single batch delete
+func (c *PiholeClient) deleteLists(ctx context.Context, lists []List) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	type batchItem struct {
+		Item string `json:"item"`
+		Type string `json:"type"`
+	}
+
+	payload := make([]batchItem, 0, len(lists))
+	for _, list := range lists {
+		payload = append(payload, batchItem{Item: list.Address, Type: list.Type})
+	}
+
+	resp, err := c.makeRequest("POST", "/api/lists:batchDelete", payload)
+	if err != nil {
+		return fmt.Errorf("failed to delete lists: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		newBatchProgress(ctx, "Deleted lists", len(lists)).add(len(lists))
+		return nil
+	}
+
+	return fmt.Errorf("failed to delete lists, status: %d, body: %s", resp.StatusCode, string(body))
+}
+
+// SetAllListsEnabled enables or disables all adlists in one pass. Allowlists are left alone, as
+// disabling them would block domains instead of unblocking them. Lists already in the requested
+// state are skipped. The changes only take effect in DNS answers after the next gravity update.
+func (c *PiholeClient) SetAllListsEnabled(ctx context.Context, enabled bool) error {
+	c.listsMu.Lock()
+	defer c.listsMu.Unlock()
+
+	lists, err := c.GetLists()
+	if err != nil {
+		return err
+	}
+
+	for _, list := range lists {
+		if list.Type != "block" || list.Enabled == enabled {
+			continue
+		}
+
+		// Stop between lists rather than in the middle of a request when Terraform is interrupted
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped before updating list '%s': %w", list.Address, err)
+		}
+
+		list.Enabled = enabled
+		if err := c.UpdateList(list); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetNetworkGateways retrieves the default gateways Pi-hole detected on its network interfaces
+func (c *PiholeClient) GetNetworkGateways() ([]Gateway, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", "/api/network/gateway", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network gateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get network gateway, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Gateway []Gateway `json:"gateway"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode network gateway: %w", err)
+	}
+
+	return apiResp.Gateway, nil
+}
+
+// GetHostInfo retrieves the hostname, kernel and hardware of the machine Pi-hole runs on. Pi-hole splits
+// these between the static host information and the system resources, so both are read, plus the version
+// information that tells whether Pi-hole runs in a container.
+func (c *PiholeClient) GetHostInfo() (*HostInfo, error) {
+	var hostResp struct {
+		Host struct {
+			Uname struct {
+				Nodename string `json:"nodename"`
+				Release  string `json:"release"`
+			} `json:"uname"`
+			Model string `json:"model"`
+			DMI   struct {
+				Product struct {
+					Name string `json:"name"`
+				} `json:"product"`
+			} `json:"dmi"`
+		} `json:"host"`
+	}
+	if err := c.getInfo("/api/info/host", "host info", &hostResp); err != nil {
+		return nil, err
+	}
+
+	var systemResp struct {
+		System struct {
+			Uptime int64 `json:"uptime"`
+			Memory struct {
+				RAM struct {
+					Total int64 `json:"total"`
+				} `json:"ram"`
+			} `json:"memory"`
+			CPU struct {
+				Nprocs int64 `json:"nprocs"`
+			} `json:"cpu"`
+		} `json:"system"`
+	}
+	if err := c.getInfo("/api/info/system", "system info", &systemResp); err != nil {
+		return nil, err
+	}
+
+	// Only the Docker image reports its own version, it's null for other installations
+	var versionResp struct {
+		Version struct {
+			Docker struct {
+				Local *string `json:"local"`
+			} `json:"docker"`
+		} `json:"version"`
+	}
+	if err := c.getInfo("/api/info/version", "version info", &versionResp); err != nil {
+		return nil, err
+	}
+
+	// Only single-board computers report a model, other machines name their product in the DMI data
+	model := hostResp.Host.Model
+	if model == "" {
+		model = hostResp.Host.DMI.Product.Name
+	}
+
+	return &HostInfo{
+		Hostname:    hostResp.Host.Uname.Nodename,
+		Kernel:      hostResp.Host.Uname.Release,
+		Model:       model,
+		CPUCores:    systemResp.System.CPU.Nprocs,
+		MemoryTotal: systemResp.System.Memory.RAM.Total,
+		Uptime:      systemResp.System.Uptime,
+		Docker:      versionResp.Version.Docker.Local != nil && *versionResp.Version.Docker.Local != "",
+	}, nil
+}
+
+// getInfo decodes the response of one of the /api/info endpoints into target
+func (c *PiholeClient) getInfo(endpoint, name string, target interface{}) error {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to get %s, status: %d, body: %s", name, resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// GetGravityInfo retrieves the number of blocked domains and the time of the last gravity update
+func (c *PiholeClient) GetGravityInfo() (*GravityInfo, error) {
+	// Add delay to prevent overwhelming the API
+	c.sleepFunc(time.Duration(c.Config.RequestDelayMs) * time.Millisecond)
+
+	resp, err := c.makeRequest("GET", "/api/stats/summary", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gravity info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get gravity info, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Gravity *GravityInfo `json:"gravity"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode gravity info: %w", err)
+	}
+
+	if apiResp.Gravity == nil {
+		return nil, fmt.Errorf("gravity info not found in response")
+	}
+
+	return apiResp.Gravity, nil
 }
@@ -1,159 +1,29 @@
 package provider
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
-)
-
-// Mock Pi-hole server for testing
-func createMockPiholeServer() *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Handle Pi-hole v6 API authentication
-		if r.URL.Path == "/api/auth" && r.Method == "POST" {
-			// Mock successful authentication response matching Pi-hole v6 format
-			authResponse := AuthResponse{
-				Session: struct {
-					Valid    bool   `json:"valid"`
-					Totp     bool   `json:"totp"`
-					Sid      string `json:"sid"`
-					Validity int    `json:"validity"`
-					Message  string `json:"message"`
-					CSRF     string `json:"csrf"`
-				}{
-					Valid:    true,
-					Totp:     false,
-					Sid:      "mock-session-id",
-					Validity: 1,
-					Message:  "success",
-					CSRF:     "mock-csrf-token",
-				},
-				Took: 0.001,
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(authResponse)
-			return
-		}
-
-		// Handle Pi-hole v6 DNS management endpoints
-		if r.URL.Path == "/api/config/dns/hosts" && r.Method == "GET" {
-			// Mock DNS records response
-			response := map[string]interface{}{
-				"config": map[string]interface{}{
-					"dns": map[string]interface{}{
-						"hosts": []string{
-							"192.168.1.100 test.example.com",
-							"192.168.1.101 server.example.com",
-						},
-					},
-				},
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(response)
-			return
-		}
-
-		if r.URL.Path == "/api/config/dns/cnameRecords" && r.Method == "GET" {
-			// Mock CNAME records response
-			response := map[string]interface{}{
-				"config": map[string]interface{}{
-					"dns": map[string]interface{}{
-						"cnameRecords": []string{
-							"www.example.com,example.com",
-							"mail.example.com,server.example.com",
-						},
-					},
-				},
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(response)
-			return
-		}
-
-		// Handle DNS record creation/modification
-		if strings.HasPrefix(r.URL.Path, "/api/config/dns/hosts/") && r.Method == "PUT" {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{"status": "success"})
-			return
-		}
-
-		// Handle CNAME record creation/modification
-		if strings.HasPrefix(r.URL.Path, "/api/config/dns/cnameRecords/") && r.Method == "PUT" {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{"status": "success"})
-			return
-		}
-
-		// Handle DNS record deletion
-		if strings.HasPrefix(r.URL.Path, "/api/config/dns/hosts/") && r.Method == "DELETE" {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{"status": "success"})
-			return
-		}
-
-		// Handle CNAME record deletion
-		if strings.HasPrefix(r.URL.Path, "/api/config/dns/cnameRecords/") && r.Method == "DELETE" {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{"status": "success"})
-			return
-		}
-
-		// Handle configuration management endpoints
-		if r.URL.Path == "/api/config/webserver" && r.Method == "GET" {
-			response := map[string]interface{}{
-				"config": map[string]interface{}{
-					"webserver": map[string]interface{}{
-						"api": map[string]interface{}{
-							"app_sudo": true, // Changed to true for the GetConfig test
-						},
-					},
-				},
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(response)
-			return
-		}
-
-		if r.URL.Path == "/api/config/webserver" && r.Method == "PUT" {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{"status": "success"})
-			return
-		}
-
-		// Handle legacy admin API for compatibility (still used by some tests)
-		if r.URL.Path == "/admin/api.php" && r.Method == "GET" && r.URL.Query().Has("summary") {
-			summaryResponse := map[string]interface{}{
-				"domains_being_blocked": 1000,
-				"dns_queries_today":     5000,
-				"ads_blocked_today":     500,
-				"ads_percentage_today":  10.5,
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(summaryResponse)
-			return
-		}
+	"time"
 
-		// For now, just handle basic endpoints for testing connection
-		// The actual DNS management API will be determined based on real Pi-hole testing
-		if r.URL.Path == "/admin/api.php" && r.Method == "GET" {
-			// Check if it has auth parameter
-			if auth := r.URL.Query().Get("auth"); auth != "" {
-				// Return success for any request with auth
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
-				return
-			}
-		}
-
-		// Default 404
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]interface{}{"error": "not found"})
-	}))
-}
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
 
 func TestNewPiholeClient(t *testing.T) {
 	server := createMockPiholeServer()
@@ -295,6 +165,21 @@ func TestPiholeClient_CreateDNSRecord(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create DNS record: %v", err)
 	}
+
+	records, err := client.GetDNSRecords()
+	if err != nil {
+		t.Fatalf("Failed to get DNS records: %v", err)
+	}
+
+	found := false
+	for _, record := range records {
+		if record.Domain == "new.example.com" && record.IP == "192.168.1.200" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected created DNS record to be returned by a subsequent read, got %v", records)
+	}
 }
 
 func TestPiholeClient_CreateCNAMERecord(t *testing.T) {
@@ -315,10 +200,61 @@ func TestPiholeClient_CreateCNAMERecord(t *testing.T) {
 		t.Fatalf("Failed to create Pi-hole client: %v", err)
 	}
 
-	err = client.CreateCNAMERecord("blog.example.com", "server.example.com")
+	err = client.CreateCNAMERecord("blog.example.com", "server.example.com", 0)
 	if err != nil {
 		t.Fatalf("Failed to create CNAME record: %v", err)
 	}
+
+	records, err := client.GetCNAMERecords()
+	if err != nil {
+		t.Fatalf("Failed to get CNAME records: %v", err)
+	}
+
+	found := false
+	for _, record := range records {
+		if record.Domain == "blog.example.com" && record.Target == "server.example.com" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected created CNAME record to be returned by a subsequent read, got %v", records)
+	}
+}
+
+func TestPiholeClient_CreateCNAMERecordsForTarget(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	// www.example.com already points elsewhere and mail.example.com already points to the target
+	domains := []string{"blog.example.com", "api.example.com", "www.example.com", "mail.example.com", "files.example.com"}
+	if err := client.CreateCNAMERecordsForTarget(context.Background(), "server.example.com", domains); err != nil {
+		t.Fatalf("Failed to create CNAME records: %v", err)
+	}
+
+	if writes := server.writeRequests(); len(writes) != 1 {
+		t.Errorf("Expected a single write for all records, got %v", writes)
+	}
+
+	expected := []string{
+		"mail.example.com,server.example.com",
+		"blog.example.com,server.example.com",
+		"api.example.com,server.example.com",
+		"www.example.com,server.example.com",
+		"files.example.com,server.example.com",
+	}
+	if records := server.cnameRecords(); !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected CNAME records %v, got %v", expected, records)
+	}
+
+	// Applying the same records again has nothing to write
+	if err := client.CreateCNAMERecordsForTarget(context.Background(), "server.example.com", domains); err != nil {
+		t.Fatalf("Failed to create CNAME records again: %v", err)
+	}
+	if writes := server.writeRequests(); len(writes) != 1 {
+		t.Errorf("Expected no further write for unchanged records, got %v", writes)
+	}
 }
 
 func TestPiholeClient_DeleteDNSRecord(t *testing.T) {
@@ -343,29 +279,453 @@ func TestPiholeClient_DeleteDNSRecord(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to delete DNS record: %v", err)
 	}
+
+	records, err := client.GetDNSRecords()
+	if err != nil {
+		t.Fatalf("Failed to get DNS records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Domain == "test.example.com" {
+			t.Errorf("Expected deleted DNS record to be gone, got %v", records)
+		}
+	}
+}
+
+func TestPiholeClient_DeleteCNAMERecord(t *testing.T) {
+
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	config := ClientConfig{
+		MaxConnections: 1,
+		RequestDelayMs: 50,
+		RetryAttempts:  1,
+		RetryBackoffMs: 100,
+		InsecureTLS:    false,
+	}
+
+	client, err := NewPiholeClient(server.URL, "test-password", config)
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	err = client.DeleteCNAMERecord("www.example.com")
+	if err != nil {
+		t.Fatalf("Failed to delete CNAME record: %v", err)
+	}
+
+	records, err := client.GetCNAMERecords()
+	if err != nil {
+		t.Fatalf("Failed to get CNAME records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Domain == "www.example.com" {
+			t.Errorf("Expected deleted CNAME record to be gone, got %v", records)
+		}
+	}
+}
+
+func TestPiholeClient_DisableKeepAlives(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	// Record the client side of every connection to tell reused connections from new ones
+	var mu sync.Mutex
+	connections := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections[r.RemoteAddr] = true
+		mu.Unlock()
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	for _, disableKeepAlives := range []bool{false, true} {
+		mu.Lock()
+		connections = make(map[string]bool)
+		mu.Unlock()
+
+		config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 1, RetryBackoffMs: 10, DisableKeepAlives: disableKeepAlives}
+
+		client, err := NewPiholeClient(server.URL, "test-password", config)
+		if err != nil {
+			t.Fatalf("Failed to create Pi-hole client: %v", err)
+		}
+
+		transport := client.HTTPClient.Transport.(*http.Transport)
+		if transport.DisableKeepAlives != disableKeepAlives {
+			t.Errorf("Expected transport DisableKeepAlives to be %t, got %t", disableKeepAlives, transport.DisableKeepAlives)
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.GetDNSRecords(); err != nil {
+				t.Fatalf("Expected requests to succeed with DisableKeepAlives=%t, got: %v", disableKeepAlives, err)
+			}
+		}
+		client.HTTPClient.CloseIdleConnections()
+
+		// One authentication and three reads
+		mu.Lock()
+		opened := len(connections)
+		mu.Unlock()
+		if disableKeepAlives && opened != 4 {
+			t.Errorf("Expected a new connection per request with keep-alives disabled, got %d connections for 4 requests", opened)
+		}
+		if !disableKeepAlives && opened != 1 {
+			t.Errorf("Expected the connection to be reused with keep-alives enabled, got %d connections", opened)
+		}
+	}
+}
+
+func TestPiholeClient_RetryBackoffCap(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	// A closed server refuses connections, which makeRequestWithRetry retries
+	refusing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	refusing.Close()
+
+	config := ClientConfig{MaxConnections: 1, RetryAttempts: 10, RetryBackoffMs: 500, RetryMaxBackoffMs: 5000}
+	maxBackoff := 5 * time.Second
+
+	testCases := []struct {
+		name string
+		url  string
+		run  func(c *PiholeClient) error
+	}{
+		{"authenticate", failing.URL, func(c *PiholeClient) error {
+			return c.authenticateWithRetry(c.Config.RetryAttempts)
+		}},
+		{"request", refusing.URL, func(c *PiholeClient) error {
+			_, err := c.makeRequestWithRetry("GET", "/api/config/dns/hosts", nil, c.Config.RetryAttempts, true)
+			return err
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sleeps []time.Duration
+			client := newPiholeClient(tc.url, "test-password", config)
+			client.sleepFunc = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+			if err := tc.run(client); err == nil {
+				t.Fatal("Expected all attempts to fail")
+			}
+
+			if len(sleeps) != config.RetryAttempts {
+				t.Fatalf("Expected %d backoff sleeps, got %d", config.RetryAttempts, len(sleeps))
+			}
+			for i, sleep := range sleeps {
+				if sleep > maxBackoff {
+					t.Errorf("Backoff before attempt %d exceeds the cap: %s > %s", i+1, sleep, maxBackoff)
+				}
+			}
+
+			// Early attempts keep the quadratic backoff, later ones are capped
+			if sleeps[0] != 500*time.Millisecond || sleeps[2] != 4500*time.Millisecond {
+				t.Errorf("Expected uncapped backoff of 500ms and 4.5s for attempts 1 and 3, got %s and %s", sleeps[0], sleeps[2])
+			}
+			if sleeps[9] != maxBackoff {
+				t.Errorf("Expected backoff for attempt 10 to be capped at %s, got %s", maxBackoff, sleeps[9])
+			}
+		})
+	}
+}
+
+func TestPiholeClient_RetryAttemptsByOperation(t *testing.T) {
+	// A closed server refuses connections, which makeRequestWithRetry retries
+	refusing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	refusing.Close()
+
+	readRetries, writeRetries := 5, 1
+
+	testCases := []struct {
+		name       string
+		config     ClientConfig
+		run        func(c *PiholeClient) error
+		expRetries int
+	}{
+		{"GET uses read count", ClientConfig{RetryAttempts: 3, ReadRetryAttempts: &readRetries, WriteRetryAttempts: &writeRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeRequest("GET", "/api/config/dns/hosts", nil)
+				return err
+			}, 5},
+		{"PUT uses write count", ClientConfig{RetryAttempts: 3, ReadRetryAttempts: &readRetries, WriteRetryAttempts: &writeRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeRequest("PUT", "/api/config/dns/hosts/192.168.1.1%20a.lan", nil)
+				return err
+			}, 1},
+		{"idempotent PATCH uses write count", ClientConfig{RetryAttempts: 3, ReadRetryAttempts: &readRetries, WriteRetryAttempts: &writeRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeIdempotentRequest("PATCH", "/api/config", map[string]interface{}{})
+				return err
+			}, 1},
+		{"POST is never retried", ClientConfig{RetryAttempts: 3, ReadRetryAttempts: &readRetries, WriteRetryAttempts: &writeRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeRequest("POST", "/api/action/restartdns", nil)
+				return err
+			}, 0},
+		{"GET falls back to retry_attempts", ClientConfig{RetryAttempts: 3, WriteRetryAttempts: &writeRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeRequest("GET", "/api/config/dns/hosts", nil)
+				return err
+			}, 3},
+		{"PUT falls back to retry_attempts", ClientConfig{RetryAttempts: 3, ReadRetryAttempts: &readRetries},
+			func(c *PiholeClient) error {
+				_, err := c.makeRequest("PUT", "/api/config/dns/hosts/192.168.1.1%20a.lan", nil)
+				return err
+			}, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.MaxConnections = 1
+			tc.config.RetryBackoffMs = 10
+
+			var sleeps []time.Duration
+			client := newPiholeClient(refusing.URL, "test-password", tc.config)
+			client.sleepFunc = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+			if err := tc.run(client); err == nil {
+				t.Fatal("Expected all attempts to fail")
+			}
+
+			// Every retry waits for its backoff first
+			if len(sleeps) != tc.expRetries {
+				t.Errorf("Expected %d retries, got %d", tc.expRetries, len(sleeps))
+			}
+		})
+	}
+}
+
+func TestPiholeClient_RetryBackoffWithoutCap(t *testing.T) {
+	client := &PiholeClient{Config: ClientConfig{RetryBackoffMs: 500}}
+
+	if backoff := client.retryBackoff(10); backoff != 50*time.Second {
+		t.Errorf("Expected uncapped backoff of 50s for attempt 10, got %s", backoff)
+	}
+}
+
+func TestPiholeClient_AuthenticateSessionLimitExceeded(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"key":"api_seats_exceeded","message":"API seats exceeded","hint":"increase webserver.api.max_sessions"},"took":0.001}`))
+	}))
+	defer server.Close()
+
+	config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 3, RetryBackoffMs: 10}
+
+	_, err := NewPiholeClient(server.URL, "test-password", config)
+	if err == nil {
+		t.Fatal("Expected authentication to fail when the session limit is reached")
+	}
+
+	if !errors.Is(err, ErrSessionLimitExceeded) {
+		t.Errorf("Expected ErrSessionLimitExceeded, got: %v", err)
+	}
+	if errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("Session limit must not be reported as an invalid password, got: %v", err)
+	}
+
+	// Retrying immediately cannot free a seat, so the limit must not be retried
+	if attempts != 1 {
+		t.Errorf("Expected a single authentication attempt, got %d", attempts)
+	}
+
+	summary, detail := clientErrorDiagnostic(err)
+	if summary != "Pi-hole API Session Limit Reached" {
+		t.Errorf("Expected session limit summary, got '%s'", summary)
+	}
+	if !strings.Contains(detail, "webserver.api.max_sessions") || !strings.Contains(detail, "not a password problem") {
+		t.Errorf("Expected detail to explain the session limit and how to resolve it, got: %s", detail)
+	}
+}
+
+func TestPiholeClient_AuthenticateInvalidPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"session":{"valid":false,"totp":false,"sid":null,"validity":-1,"message":"password incorrect"},"took":0.001}`))
+	}))
+	defer server.Close()
+
+	config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 3, RetryBackoffMs: 10}
+
+	_, err := NewPiholeClient(server.URL, "wrong-password", config)
+	if !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("Expected ErrInvalidPassword, got: %v", err)
+	}
+	if errors.Is(err, ErrSessionLimitExceeded) {
+		t.Errorf("Invalid password must not be reported as a session limit, got: %v", err)
+	}
+
+	summary, _ := clientErrorDiagnostic(err)
+	if summary != "Invalid Pi-hole Password" {
+		t.Errorf("Expected invalid password summary, got '%s'", summary)
+	}
+}
+
+func TestPiholeClient_AuthenticateUnreachable(t *testing.T) {
+	// A closed server refuses connections on its address
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 1, RetryBackoffMs: 10}
+
+	_, err := NewPiholeClient(serverURL, "test-password", config)
+	if !errors.Is(err, ErrAuthUnreachable) {
+		t.Fatalf("Expected ErrAuthUnreachable, got: %v", err)
+	}
+	if errors.Is(err, ErrInvalidPassword) || errors.Is(err, ErrSessionLimitExceeded) {
+		t.Errorf("A connection failure must not be reported as a password or session problem, got: %v", err)
+	}
+
+	summary, detail := clientErrorDiagnostic(err)
+	if summary != "Pi-hole API Unreachable" {
+		t.Errorf("Expected unreachable summary, got '%s'", summary)
+	}
+	if !strings.Contains(detail, "connection refused") {
+		t.Errorf("Expected detail to include the underlying error, got: %s", detail)
+	}
+}
+
+func TestPiholeClient_AuthenticateEmptyResponse(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// A misconfigured proxy answering with 200 and nothing else
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("  \n"))
+	}))
+	defer server.Close()
+
+	config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 3, RetryBackoffMs: 1000}
+
+	start := time.Now()
+	_, err := NewPiholeClient(server.URL, "test-password", config)
+	if !errors.Is(err, ErrEmptyResponse) {
+		t.Fatalf("Expected ErrEmptyResponse, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "verify the url") {
+		t.Errorf("Expected the error to point at the url, got: %v", err)
+	}
+
+	// An empty response isn't transient, so it must fail on the first attempt without backoff
+	if attempts != 1 {
+		t.Errorf("Expected a single authentication attempt, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected a fast failure, took %s", elapsed)
+	}
+
+	summary, _ := clientErrorDiagnostic(err)
+	if summary != "Empty Pi-hole API Response" {
+		t.Errorf("Expected empty response summary, got '%s'", summary)
+	}
+}
+
+func TestPiholeClient_EmptyDataResponse(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config/dns/hosts" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	_, err := client.GetDNSRecords()
+	if !errors.Is(err, ErrEmptyResponse) {
+		t.Fatalf("Expected ErrEmptyResponse for an empty read, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "unmarshal") {
+		t.Errorf("Expected a clear error instead of an unmarshal error, got: %v", err)
+	}
+}
+
+func TestPiholeClient_WhitespaceOnlyDataResponse(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config/dns/hosts" {
+			w.Write([]byte(" \r\n\t\n"))
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	if _, err := client.GetDNSRecords(); !errors.Is(err, ErrEmptyResponse) {
+		t.Fatalf("Expected ErrEmptyResponse for a whitespace-only read, got: %v", err)
+	}
 }
 
-func TestPiholeClient_DeleteCNAMERecord(t *testing.T) {
+func TestPiholeClient_GzipLargeResponse(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
 
-	server := createMockPiholeServer()
+	hosts := make([]string, 20000)
+	for i := range hosts {
+		hosts[i] = fmt.Sprintf("10.%d.%d.%d host-%05d.example.com", i/65536, (i/256)%256, i%256, i)
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"config": map[string]interface{}{"dns": map[string]interface{}{"hosts": hosts}},
+	})
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(payload)
+	gz.Close()
+
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/config/dns/hosts" {
+			fake.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(acceptEncoding, "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(compressed.Bytes())
+			return
+		}
+		w.Write(payload)
+	}))
 	defer server.Close()
 
-	config := ClientConfig{
-		MaxConnections: 1,
-		RequestDelayMs: 50,
-		RetryAttempts:  1,
-		RetryBackoffMs: 100,
-		InsecureTLS:    false,
-	}
+	client := newTestClient(t, server.URL)
 
-	client, err := NewPiholeClient(server.URL, "test-password", config)
+	records, err := client.GetDNSRecords()
 	if err != nil {
-		t.Fatalf("Failed to create Pi-hole client: %v", err)
+		t.Fatalf("GetDNSRecords failed: %v", err)
 	}
 
-	err = client.DeleteCNAMERecord("www.example.com")
-	if err != nil {
-		t.Fatalf("Failed to delete CNAME record: %v", err)
+	if !strings.Contains(acceptEncoding, "gzip") {
+		t.Errorf("Expected the transport to ask for gzip, got Accept-Encoding %q", acceptEncoding)
+	}
+	if len(records) != len(hosts) {
+		t.Fatalf("Expected %d records, got %d", len(hosts), len(records))
+	}
+	if last := records[len(records)-1]; last.Domain != "host-19999.example.com" || last.IP != "10.0.78.31" {
+		t.Errorf("Unexpected last record: %+v", last)
 	}
 }
 
@@ -414,9 +774,13 @@ func TestPiholeClient_RetryLogic(t *testing.T) {
 		InsecureTLS:    false,
 	}
 
+	// Record the backoff instead of waiting for it
+	var sleeps []time.Duration
+	client := newPiholeClient(server.URL, "test-password", config)
+	client.sleepFunc = func(d time.Duration) { sleeps = append(sleeps, d) }
+
 	// Authentication now implements retry logic, so it should eventually succeed
-	client, err := NewPiholeClient(server.URL, "test-password", config)
-	if err != nil {
+	if err := client.authenticate(); err != nil {
 		t.Fatalf("Expected authentication to succeed after retries, but got error: %v", err)
 	}
 
@@ -425,8 +789,20 @@ func TestPiholeClient_RetryLogic(t *testing.T) {
 	}
 
 	// Verify that it actually took multiple attempts
-	if attempts <= 2 {
-		t.Errorf("Expected at least 3 attempts due to failures, but only made %d attempts", attempts)
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts due to failures, but made %d attempts", attempts)
+	}
+
+	// The backoff grows quadratically with the attempt: 1*1*50ms, 2*2*50ms
+	expected := []time.Duration{50 * time.Millisecond, 200 * time.Millisecond}
+	if len(sleeps) != len(expected) {
+		t.Fatalf("Expected backoff sleeps %v, got %v", expected, sleeps)
+	}
+	for i := range expected {
+		if sleeps[i] != expected[i] {
+			t.Errorf("Expected backoff sleeps %v, got %v", expected, sleeps)
+			break
+		}
 	}
 }
 
@@ -539,6 +915,68 @@ func TestIsRetryableError(t *testing.T) {
 	}
 }
 
+func TestPiholeClient_RetriesOnlyIdempotentRequests(t *testing.T) {
+	testCases := []struct {
+		name             string
+		run              func(c *PiholeClient) (*http.Response, error)
+		expectedAttempts int
+	}{
+		{"POST action", func(c *PiholeClient) (*http.Response, error) {
+			return c.makeRequest("POST", "/api/action/restartdns", nil)
+		}, 1},
+		{"PUT", func(c *PiholeClient) (*http.Response, error) {
+			return c.makeRequest("PUT", "/api/config/dns/hosts/192.168.1.10%20nas.example.com", nil)
+		}, 2},
+		{"opted in PATCH", func(c *PiholeClient) (*http.Response, error) {
+			return c.makeIdempotentRequest("PATCH", "/api/config", map[string]interface{}{})
+		}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The first request reaches the server but the connection drops before the response is sent
+			var mu sync.Mutex
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				attempts++
+				first := attempts == 1
+				mu.Unlock()
+
+				if first {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err == nil {
+						conn.Close()
+					}
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := newPiholeClient(server.URL, "test-password", ClientConfig{MaxConnections: 1, RetryAttempts: 3, RetryBackoffMs: 1})
+			client.sleepFunc = func(time.Duration) {}
+
+			resp, err := tc.run(client)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if attempts != tc.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+			if tc.expectedAttempts == 1 && err == nil {
+				t.Error("Expected the dropped POST to fail instead of being sent again")
+			}
+			if tc.expectedAttempts > 1 && err != nil {
+				t.Errorf("Expected the retry to succeed, got %v", err)
+			}
+		})
+	}
+}
+
 func TestClientConfig_Defaults(t *testing.T) {
 	config := ClientConfig{
 		MaxConnections: 1,
@@ -727,6 +1165,29 @@ func TestPiholeClient_GetConfig(t *testing.T) {
 	}
 }
 
+func TestPiholeClient_GetConfig_KeyNotFound(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	config := ClientConfig{
+		MaxConnections: 1,
+		RequestDelayMs: 50,
+		RetryAttempts:  1,
+		RetryBackoffMs: 100,
+		InsecureTLS:    false,
+	}
+
+	client, err := NewPiholeClient(server.URL, "test-password", config)
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	_, err = client.GetConfig("webserver.api.renamed_key")
+	if !errors.Is(err, ErrConfigKeyNotFound) {
+		t.Errorf("Expected ErrConfigKeyNotFound, got %v", err)
+	}
+}
+
 func TestPiholeClient_SetConfig(t *testing.T) {
 	server := createMockPiholeServer()
 	defer server.Close()
@@ -817,3 +1278,445 @@ func TestPiholeClient_SetWebserverConfig(t *testing.T) {
 		t.Fatalf("Failed to set webserver configuration: %v", err)
 	}
 }
+
+func TestPiholeClient_SetWebserverConfigReportsRejectedKeys(t *testing.T) {
+	// Pi-hole accepts the PUT but reports that one of the keys was not applied
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"processed":{"success":[{"item":"api.app_sudo"}],"errors":[{"item":"port","error":"invalid port specification"}]}}`)
+	}))
+	defer server.Close()
+
+	client := newPiholeClient(server.URL, "test-password", ClientConfig{MaxConnections: 1})
+	client.sleepFunc = func(time.Duration) {}
+
+	err := client.SetWebserverConfig(map[string]interface{}{
+		"port": "not-a-port",
+		"api":  map[string]interface{}{"app_sudo": true},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for the rejected key")
+	}
+	if !strings.Contains(err.Error(), "port (invalid port specification)") {
+		t.Errorf("Expected the error to name the rejected key and reason, got: %v", err)
+	}
+}
+
+func TestPiholeClient_VerifyWritesDetectsDroppedRecords(t *testing.T) {
+	testCases := []struct {
+		name   string
+		create func(c *PiholeClient) error
+	}{
+		{"DNS record", func(c *PiholeClient) error {
+			return c.CreateDNSRecord("nas.example.com", "192.168.1.20")
+		}},
+		{"CNAME record", func(c *PiholeClient) error {
+			return c.CreateCNAMERecord("files.example.com", "nas.example.com", 0)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakePihole(map[string]map[string]interface{}{
+				"dns": {"hosts": []string{}, "cnameRecords": []string{}},
+			})
+			defer fake.Close()
+
+			// Pi-hole acknowledges the new record but never stores it
+			dropping := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/api/config/dns/") {
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, `{"took":0.001}`)
+					return
+				}
+				fake.Config.Handler.ServeHTTP(w, r)
+			}))
+			defer dropping.Close()
+
+			for _, verify := range []bool{false, true} {
+				client, err := NewPiholeClient(dropping.URL, "test-password", ClientConfig{MaxConnections: 1, VerifyWrites: verify})
+				if err != nil {
+					t.Fatalf("Failed to create Pi-hole client: %v", err)
+				}
+
+				err = tc.create(client)
+				if verify && (err == nil || !strings.Contains(err.Error(), "missing when read back")) {
+					t.Errorf("Expected verify_writes to catch the dropped record, got %v", err)
+				}
+				if !verify && err != nil {
+					t.Errorf("Expected the dropped record to go unnoticed without verify_writes, got %v", err)
+				}
+			}
+
+			// A record that Pi-hole stores passes the verification
+			client, err := NewPiholeClient(fake.URL, "test-password", ClientConfig{MaxConnections: 1, VerifyWrites: true})
+			if err != nil {
+				t.Fatalf("Failed to create Pi-hole client: %v", err)
+			}
+			if err := tc.create(client); err != nil {
+				t.Errorf("Expected a stored record to pass verification, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPiholeClient_GetConfigSection(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "LOCAL", "interface": "eth0"},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	dnsConfig, err := client.GetConfigSection("dns")
+	if err != nil {
+		t.Fatalf("Failed to get dns configuration section: %v", err)
+	}
+
+	if dnsConfig["listeningMode"] != "LOCAL" {
+		t.Errorf("Expected listeningMode 'LOCAL', got %v", dnsConfig["listeningMode"])
+	}
+
+	if _, err := client.GetConfigSection("unknown"); err == nil {
+		t.Error("Expected error for unknown configuration section")
+	}
+}
+
+func TestPiholeClient_SetConfigSection(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "LOCAL", "interface": ""},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	err := client.SetConfigSection("dns", map[string]interface{}{"listeningMode": "ALL"})
+	if err != nil {
+		t.Fatalf("Failed to set dns configuration section: %v", err)
+	}
+
+	if count := server.requestCount("PATCH /api/config"); count != 1 {
+		t.Fatalf("Expected exactly one PATCH request, got %d", count)
+	}
+
+	dnsConfig := server.section("dns")
+	if dnsConfig["listeningMode"] != "ALL" {
+		t.Errorf("Expected listeningMode 'ALL', got %v", dnsConfig["listeningMode"])
+	}
+	if _, exists := dnsConfig["interface"]; !exists {
+		t.Error("Expected untouched keys to be preserved by the PATCH")
+	}
+}
+
+func TestPiholeClient_MinTLSVersion(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	// A Pi-hole that can't speak anything newer than TLS 1.2
+	server := httptest.NewUnstartedServer(fake.Config.Handler)
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		minTLSVersion uint16
+		expectErr     bool
+	}{
+		{"default", 0, false},
+		{"TLS 1.2", tls.VersionTLS12, false},
+		{"TLS 1.3", tls.VersionTLS13, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := ClientConfig{MaxConnections: 1, RequestDelayMs: 0, RetryAttempts: 0, RetryBackoffMs: 10, InsecureTLS: true, MinTLSVersion: tc.minTLSVersion}
+
+			_, err := NewPiholeClient(server.URL, "test-password", config)
+			if tc.expectErr && err == nil {
+				t.Error("Expected the connection to fail below the configured minimum TLS version")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("Expected the client to connect, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestPiholeClient_GetCNAMERecordsWithoutEndpoint(t *testing.T) {
+	// A Pi-hole without dns.cnameRecords answers the CNAME endpoint with 404
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{"192.168.1.100 test.example.com"}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	records, err := client.GetCNAMERecords()
+	if err != nil {
+		t.Fatalf("Expected a missing CNAME endpoint to be treated as no records, got: %v", err)
+	}
+	if records == nil || len(records) != 0 {
+		t.Errorf("Expected an empty list of CNAME records, got %v", records)
+	}
+
+	// DNS-only workflows keep working, including the CNAME collision check on create
+	if err := client.CreateDNSRecord("nas.example.com", "192.168.1.20"); err != nil {
+		t.Errorf("Expected DNS records to be manageable without the CNAME endpoint, got: %v", err)
+	}
+}
+
+func TestPiholeClient_GetCNAMERecordsServerError(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config/dns/cnameRecords" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	if _, err := client.GetCNAMERecords(); err == nil {
+		t.Error("Expected errors other than 404 to still fail")
+	}
+}
+
+func TestPiholeClient_LogsRedactedWriteBodies(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	var output bytes.Buffer
+	client.logCtx = tflogtest.RootLogger(context.Background(), &output)
+
+	err := client.SetConfigSection("webserver", map[string]interface{}{
+		"api":     map[string]interface{}{"app_sudo": true, "pwhash": "$BALLOON-SHA256$secret", "password": "hunter2"},
+		"session": map[string]interface{}{"timeout": 300},
+	})
+	if err != nil {
+		t.Fatalf("SetConfigSection failed: %v", err)
+	}
+	if _, err := client.GetDNSRecords(); err != nil {
+		t.Fatalf("GetDNSRecords failed: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	// Only the write is logged, reads have no body
+	if len(entries) != 1 {
+		t.Fatalf("Expected one logged request body, got %d: %v", len(entries), entries)
+	}
+
+	entry := entries[0]
+	if entry["@level"] != "trace" || entry["method"] != "PATCH" || entry["endpoint"] != "/api/config" {
+		t.Errorf("Expected a trace entry for PATCH /api/config, got %v", entry)
+	}
+
+	body, _ := entry["body"].(string)
+	if !strings.Contains(body, `"timeout":300`) || !strings.Contains(body, `"app_sudo":true`) {
+		t.Errorf("Expected the logged body to contain the written settings, got: %s", body)
+	}
+	if strings.Contains(body, "secret") || strings.Contains(body, "hunter2") {
+		t.Errorf("Expected sensitive values to be redacted, got: %s", body)
+	}
+	if !strings.Contains(body, `"pwhash":"***"`) || !strings.Contains(body, `"password":"***"`) {
+		t.Errorf("Expected the sensitive keys to be kept with redacted values, got: %s", body)
+	}
+}
+
+func TestPiholeClient_LogsBatchProgress(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{"dns": {"cnameRecords": []interface{}{}}})
+	defer server.Close()
+
+	var existing, desired []List
+	for i := range 120 {
+		list := List{Address: fmt.Sprintf("https://lists.example.com/%d.txt", i), Type: "block", Enabled: true}
+		existing = append(existing, list)
+		list.Enabled = false
+		desired = append(desired, list)
+	}
+	for i := range 3 {
+		desired = append(desired, List{Address: fmt.Sprintf("https://new.example.com/%d.txt", i), Type: "block", Enabled: true})
+	}
+	server.setLists(existing...)
+
+	client := newTestClient(t, server.URL)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := client.ApplyLists(ctx, desired, nil); err != nil {
+		t.Fatalf("ApplyLists failed: %v", err)
+	}
+	if err := client.CreateCNAMERecordsForTarget(ctx, "server.example.com", []string{"a.example.com", "b.example.com"}); err != nil {
+		t.Fatalf("CreateCNAMERecordsForTarget failed: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	var progress []string
+	for _, entry := range entries {
+		if _, ok := entry["total"]; ok {
+			progress = append(progress, entry["@message"].(string))
+		}
+	}
+
+	// Updates are logged every batchProgressInterval lists, the single create request once it is done
+	expected := []string{
+		"Updated lists 50/120",
+		"Updated lists 100/120",
+		"Updated lists 120/120",
+		"Created lists 3/3",
+		"Created CNAME records for server.example.com 2/2",
+	}
+	if !slices.Equal(progress, expected) {
+		t.Errorf("Expected progress lines %v, got %v", expected, progress)
+	}
+}
+
+func TestPiholeClient_LimitsConcurrentRequests(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	// Hold every request for a moment and record how many are in flight at once
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewPiholeClient(server.URL, "test-password", ClientConfig{
+		MaxConnections: 2,
+		RetryAttempts:  1,
+		RetryBackoffMs: 10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+	// A transport without a connection limit shows that the client enforces the limit itself
+	client.HTTPClient.Transport = &http.Transport{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCNAMERecords(); err != nil {
+				t.Errorf("GetCNAMERecords failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight := atomic.LoadInt32(&maxInFlight); maxInFlight != 2 {
+		t.Errorf("Expected at most 2 requests in flight, and the limit to be used, got %d", maxInFlight)
+	}
+}
+
+func TestPiholeClient_LongRunningActionsOutlastRequestTimeout(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	// Every call besides authentication takes longer than the request timeout, scaled down from 90s against 60s
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth" {
+			time.Sleep(90 * time.Millisecond)
+		}
+		if r.URL.Path == "/api/action/gravity" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("  [✓] Done.\n"))
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewPiholeClient(server.URL, "test-password", ClientConfig{
+		MaxConnections: 1,
+		RetryAttempts:  0,
+		RetryBackoffMs: 10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+	client.requestTimeout = 60 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.UpdateGravity(ctx); err != nil {
+		t.Errorf("Expected the gravity update to run past the request timeout, got: %v", err)
+	}
+
+	_, err = client.GetDNSRecords()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the DNS request to time out, got: %v", err)
+	}
+}
+
+func TestPiholeClient_SnapshotFetchesOncePerWindow(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.requestSlots = newRequestSlots(4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			snapshot, err := client.Snapshot(context.Background())
+			if err != nil {
+				t.Errorf("Snapshot failed: %v", err)
+				return
+			}
+			if len(snapshot.DNSRecords) != 2 || len(snapshot.CNAMERecords) != 2 {
+				t.Errorf("Expected 2 DNS and 2 CNAME records, got %d and %d", len(snapshot.DNSRecords), len(snapshot.CNAMERecords))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, request := range []string{"GET /api/config/dns/hosts", "GET /api/config/dns/cnameRecords"} {
+		if count := server.requestCount(request); count != 1 {
+			t.Errorf("Expected a single %s for all snapshots, got %d", request, count)
+		}
+	}
+
+	// A write drops the snapshot, so the next one sees the new record
+	if err := client.CreateDNSRecord("nas.example.com", "192.168.1.20"); err != nil {
+		t.Fatalf("CreateDNSRecord failed: %v", err)
+	}
+	snapshot, err := client.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if len(snapshot.DNSRecords) != 3 {
+		t.Errorf("Expected the snapshot after the write to hold 3 DNS records, got %d", len(snapshot.DNSRecords))
+	}
+	if count := server.requestCount("GET /api/config/dns/cnameRecords"); count != 2 {
+		t.Errorf("Expected the write to cause a second snapshot fetch, got %d CNAME fetches", count)
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -12,6 +13,10 @@ import (
 
 var _ datasource.DataSource = &CNAMERecordDataSource{}
 
+// maxCNAMEChainLength bounds how many CNAME records are followed when resolving effective_ip, so misconfigured
+// chains that loop back on themselves terminate
+const maxCNAMEChainLength = 16
+
 func NewCNAMERecordDataSource() datasource.DataSource {
 	return &CNAMERecordDataSource{}
 }
@@ -21,9 +26,10 @@ type CNAMERecordDataSource struct {
 }
 
 type CNAMERecordDataSourceSingleModel struct {
-	ID     types.String `tfsdk:"id"`
-	Domain types.String `tfsdk:"domain"`
-	Target types.String `tfsdk:"target"`
+	ID          types.String `tfsdk:"id"`
+	Domain      types.String `tfsdk:"domain"`
+	Target      types.String `tfsdk:"target"`
+	EffectiveIP types.String `tfsdk:"effective_ip"`
 }
 
 func (d *CNAMERecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +56,11 @@ func (d *CNAMERecordDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "The target domain name that the CNAME points to",
 				Computed:            true,
 			},
+			"effective_ip": schema.StringAttribute{
+				MarkdownDescription: "The IP address the CNAME finally resolves to, following further CNAME records to a " +
+					"local DNS record. Null if the chain ends at a domain without a local DNS record or loops back on itself",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -83,11 +94,12 @@ func (d *CNAMERecordDataSource) Read(ctx context.Context, req datasource.ReadReq
 	domain := data.Domain.ValueString()
 
 	// Get all CNAME records from Pi-hole
-	records, err := d.client.GetCNAMERecords()
+	snapshot, err := d.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", "Unable to read CNAME records: "+err.Error())
 		return
 	}
+	records := snapshot.CNAMERecords
 
 	// Find the specific record
 	var foundRecord *CNAMERecord
@@ -110,7 +122,35 @@ func (d *CNAMERecordDataSource) Read(ctx context.Context, req datasource.ReadReq
 	data.ID = types.StringValue(domain)
 	data.Domain = types.StringValue(foundRecord.Domain)
 	data.Target = types.StringValue(foundRecord.Target)
+	data.EffectiveIP = types.StringPointerValue(resolveCNAMETarget(foundRecord.Target, snapshot))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// resolveCNAMETarget follows target through the local CNAME records until it reaches a local DNS record and returns
+// its IP. It returns nil if the chain leaves Pi-hole's local records or is longer than maxCNAMEChainLength.
+func resolveCNAMETarget(target string, snapshot *RecordSnapshot) *string {
+	for range maxCNAMEChainLength {
+		for _, record := range snapshot.DNSRecords {
+			if strings.EqualFold(record.Domain, target) {
+				ip := record.IP
+				return &ip
+			}
+		}
+
+		next := ""
+		for _, record := range snapshot.CNAMERecords {
+			if strings.EqualFold(record.Domain, target) {
+				next = record.Target
+				break
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		target = next
+	}
+
+	return nil
+}
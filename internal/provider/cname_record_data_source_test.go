@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -172,6 +174,57 @@ func TestPiholeCNAMERecordDataSource_Schema(t *testing.T) {
 	}
 }
 
+func TestPiholeCNAMERecordDataSource_EffectiveIP(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {
+			"hosts": []string{"192.168.1.101 server.example.com"},
+			"cnameRecords": []string{
+				"mail.example.com,server.example.com",
+				"webmail.example.com,mail.example.com",
+				"www.example.com,example.org",
+				"loop-a.example.com,loop-b.example.com",
+				"loop-b.example.com,loop-a.example.com",
+			},
+		},
+	})
+	defer server.Close()
+
+	tests := []struct {
+		domain   string
+		expected string // empty if effective_ip should be null
+	}{
+		{domain: "mail.example.com", expected: "192.168.1.101"},
+		{domain: "webmail.example.com", expected: "192.168.1.101"},
+		{domain: "www.example.com", expected: ""},
+		{domain: "loop-a.example.com", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			d := NewCNAMERecordDataSource()
+			d.(*CNAMERecordDataSource).client = newTestClient(t, server.URL)
+
+			resp := testDataSourceRead(t, d, map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, tt.domain),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			var data CNAMERecordDataSourceSingleModel
+			resp.State.Get(context.Background(), &data)
+
+			if tt.expected == "" {
+				if !data.EffectiveIP.IsNull() {
+					t.Errorf("Expected effective_ip to be null, got %s", data.EffectiveIP)
+				}
+			} else if data.EffectiveIP.ValueString() != tt.expected {
+				t.Errorf("Expected effective_ip %s, got %s", tt.expected, data.EffectiveIP)
+			}
+		})
+	}
+}
+
 // Test configuration functions
 func testAccPiholeCNAMERecordDataSourceConfig_basic() string {
 	return fmt.Sprintf(`
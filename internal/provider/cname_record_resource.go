@@ -4,18 +4,23 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/publicsuffix"
 )
 
 var _ resource.Resource = &CNAMERecordResource{}
+var _ resource.ResourceWithModifyPlan = &CNAMERecordResource{}
 
 func NewCNAMERecordResource() resource.Resource {
 	return &CNAMERecordResource{}
@@ -29,6 +34,9 @@ type CNAMERecordResourceModel struct {
 	ID     types.String `tfsdk:"id"`
 	Domain types.String `tfsdk:"domain"`
 	Target types.String `tfsdk:"target"`
+	TTL    types.Int64  `tfsdk:"ttl"`
+
+	RequireTarget types.Bool `tfsdk:"require_target"`
 }
 
 func (r *CNAMERecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,6 +78,21 @@ func (r *CNAMERecordResource) Schema(ctx context.Context, req resource.SchemaReq
 					),
 				},
 			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "TTL in seconds Pi-hole answers the CNAME with. Leave unset to use Pi-hole's default",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"require_target": schema.BoolAttribute{
+				MarkdownDescription: "Whether creating the record fails unless `target` already is a local DNS A record or " +
+					"CNAME in Pi-hole, to catch dangling CNAMEs early. Targets under a public TLD are treated as external " +
+					"and not checked (default: `false`)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -91,6 +114,10 @@ func (r *CNAMERecordResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+func (r *CNAMERecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	enforceLocalSuffix(ctx, r.client, req, resp)
+}
+
 func (r *CNAMERecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data CNAMERecordResourceModel
 
@@ -100,7 +127,44 @@ func (r *CNAMERecordResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	err := r.client.CreateCNAMERecord(data.Domain.ValueString(), data.Target.ValueString())
+	// A domain can't be both a CNAME and an A record. Resources can't see each other's configuration
+	// at plan time, so the collision is caught against what is already stored in Pi-hole.
+	dnsRecords, err := r.client.GetDNSRecords()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records, got error: %s", err))
+		return
+	}
+	for _, record := range dnsRecords {
+		if strings.EqualFold(record.Domain, data.Domain.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("domain"),
+				"Conflicting DNS Record",
+				fmt.Sprintf("'%s' already has a DNS A record pointing to %s. A domain can't be both a CNAME and an A record, "+
+					"remove the A record or choose a different domain.", data.Domain.ValueString(), record.IP),
+			)
+			return
+		}
+	}
+
+	if data.RequireTarget.ValueBool() && !isExternalTarget(data.Target.ValueString(), r.client.Config.LocalSuffixes) {
+		exists, err := r.localTargetExists(data.Target.ValueString(), dnsRecords)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CNAME records, got error: %s", err))
+			return
+		}
+		if !exists {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("target"),
+				"CNAME Target Not Found",
+				fmt.Sprintf("'%s' is neither a local DNS A record nor a CNAME in Pi-hole, so '%s' would not resolve. "+
+					"Create the target first, e.g. with depends_on, or set require_target to false.",
+					data.Target.ValueString(), data.Domain.ValueString()),
+			)
+			return
+		}
+	}
+
+	err = r.client.CreateCNAMERecord(data.Domain.ValueString(), data.Target.ValueString(), data.TTL.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create CNAME record, got error: %s", err))
 		return
@@ -120,16 +184,21 @@ func (r *CNAMERecordResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	records, err := r.client.GetCNAMERecords()
+	snapshot, err := r.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CNAME records, got error: %s", err))
 		return
 	}
 
 	found := false
-	for _, record := range records {
+	for _, record := range snapshot.CNAMERecords {
 		if record.Domain == data.Domain.ValueString() {
 			data.Target = types.StringValue(record.Target)
+			if record.TTL > 0 {
+				data.TTL = types.Int64Value(record.TTL)
+			} else {
+				data.TTL = types.Int64Null()
+			}
 			found = true
 			break
 		}
@@ -140,6 +209,11 @@ func (r *CNAMERecordResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Imported records, and state written before the attribute existed, have no value for it yet
+	if data.RequireTarget.IsNull() {
+		data.RequireTarget = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -152,12 +226,23 @@ func (r *CNAMERecordResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	err := r.client.UpdateCNAMERecord(data.Domain.ValueString(), data.Target.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update CNAME record, got error: %s", err))
+	var state CNAMERecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Re-creating an unchanged record would only leave the domain unresolved in between
+	if data.Target.ValueString() != state.Target.ValueString() || !data.TTL.Equal(state.TTL) {
+		err := r.client.UpdateCNAMERecord(data.Domain.ValueString(), data.Target.ValueString(), data.TTL.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update CNAME record, got error: %s", err))
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -184,3 +269,38 @@ func (r *CNAMERecordResource) ImportState(ctx context.Context, req resource.Impo
 	// Set the ID to match the domain for consistency
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
+
+// localTargetExists reports whether target is one of Pi-hole's local DNS A records or CNAMEs
+func (r *CNAMERecordResource) localTargetExists(target string, dnsRecords []DNSRecord) (bool, error) {
+	target = strings.TrimSuffix(target, ".")
+	for _, record := range dnsRecords {
+		if strings.EqualFold(record.Domain, target) {
+			return true, nil
+		}
+	}
+
+	cnameRecords, err := r.client.GetCNAMERecords()
+	if err != nil {
+		return false, err
+	}
+	for _, record := range cnameRecords {
+		if strings.EqualFold(record.Domain, target) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isExternalTarget reports whether target lies under a public TLD, so it is resolved by the upstream servers
+// rather than by Pi-hole's local records. Domains under the provider's local suffixes and home.arpa (RFC 8375)
+// are always local, as are single labels and private TLDs like lan or local.
+func isExternalTarget(target string, localSuffixes []string) bool {
+	target = strings.ToLower(strings.TrimSuffix(target, "."))
+	if hasLocalSuffix(target, append([]string{"home.arpa"}, localSuffixes...)) {
+		return false
+	}
+
+	_, icann := publicsuffix.PublicSuffix(target)
+	return icann
+}
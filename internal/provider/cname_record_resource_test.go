@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -62,6 +68,33 @@ func TestAccPiholeCNAMERecord_disappears(t *testing.T) {
 	})
 }
 
+func TestAccPiholeCNAMERecord_externalTargetChange(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPiholeCNAMERecordConfig("drift.example.com", "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPiholeCNAMERecordExists("pihole_cname_record.test"),
+					testAccCheckPiholeCNAMERecordChangeTarget("pihole_cname_record.test", "elsewhere.example.com"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// The refresh picks up the changed target and the plan restores the configured one
+			{
+				Config: testAccPiholeCNAMERecordConfig("drift.example.com", "example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_cname_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("pihole_cname_record.test", "target", "example.com"),
+			},
+		},
+	})
+}
+
 func TestAccPiholeCNAMERecord_invalidDomain(t *testing.T) {
 	testAccPreCheck(t)
 	resource.Test(t, resource.TestCase{
@@ -224,7 +257,7 @@ func testAccCheckPiholeCNAMERecordDestroy(resourceName string) resource.TestChec
 			return fmt.Errorf("PIHOLE_URL and PIHOLE_PASSWORD must be set for disappears test")
 		}
 
-		client, err := getOrCreateClient(url, password, config)
+		client, err := getOrCreateClient(context.Background(), url, password, config)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %v", err)
 		}
@@ -240,6 +273,34 @@ func testAccCheckPiholeCNAMERecordDestroy(resourceName string) resource.TestChec
 }
 
 // Unit tests for CNAME record resource
+// testAccCheckPiholeCNAMERecordChangeTarget points the CNAME record to another target outside of Terraform
+func testAccCheckPiholeCNAMERecordChangeTarget(resourceName, target string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		config := ClientConfig{
+			MaxConnections: 1,
+			RequestDelayMs: 300,
+			RetryAttempts:  3,
+			RetryBackoffMs: 500,
+		}
+
+		client, err := getOrCreateClient(context.Background(), os.Getenv("PIHOLE_URL"), os.Getenv("PIHOLE_PASSWORD"), config)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %v", err)
+		}
+
+		if err := client.UpdateCNAMERecord(rs.Primary.ID, target, 0); err != nil {
+			return fmt.Errorf("failed to change CNAME record externally: %v", err)
+		}
+
+		return nil
+	}
+}
+
 func TestCNAMERecordResource_Schema(t *testing.T) {
 	resource := NewCNAMERecordResource()
 
@@ -318,7 +379,7 @@ func BenchmarkCNAMERecordCreate(b *testing.B) {
 		domain := fmt.Sprintf("test%d.example.com", i)
 		target := fmt.Sprintf("server%d.example.com", i)
 
-		err := client.CreateCNAMERecord(domain, target)
+		err := client.CreateCNAMERecord(domain, target, 0)
 		if err != nil {
 			b.Fatalf("Failed to create CNAME record: %v", err)
 		}
@@ -405,3 +466,303 @@ func TestCNAMERecord_URLEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestCNAMERecordResource_CreateRejectsARecordCollision(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	r := NewCNAMERecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// server.example.com already is an A record in the fake
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "server.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error for a CNAME on a domain that has an A record")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Conflicting DNS Record" {
+		t.Errorf("Expected 'Conflicting DNS Record', got '%s'", summary)
+	}
+	for _, record := range server.cnameRecords() {
+		if strings.HasPrefix(record, "server.example.com,") {
+			t.Errorf("Expected no CNAME to be created, got %v", server.cnameRecords())
+		}
+	}
+}
+
+func TestCNAMERecordResource_RequireTarget(t *testing.T) {
+	testCases := []struct {
+		name          string
+		target        string
+		requireTarget bool
+		expectError   bool
+	}{
+		{"missing local target rejected", "ghost.lan", true, true},
+		{"missing home.arpa target rejected", "ghost.home.arpa", true, true},
+		{"missing single label target rejected", "ghost", true, true},
+		{"existing A record target", "nas.lan", true, false},
+		{"existing CNAME target", "files.lan", true, false},
+		{"target matched case-insensitively", "NAS.lan", true, false},
+		{"external target not checked", "cdn.example.com", true, false},
+		{"missing target allowed without flag", "ghost.lan", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newFakePihole(map[string]map[string]interface{}{
+				"dns": {
+					"hosts":        []interface{}{"192.168.1.10 nas.lan"},
+					"cnameRecords": []interface{}{"files.lan,nas.lan"},
+				},
+			})
+			defer server.Close()
+
+			r := NewCNAMERecordResource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			resp := testResourceCreate(t, r, map[string]tftypes.Value{
+				"domain":         tftypes.NewValue(tftypes.String, "alias.lan"),
+				"target":         tftypes.NewValue(tftypes.String, tc.target),
+				"require_target": tftypes.NewValue(tftypes.Bool, tc.requireTarget),
+			})
+
+			created := slices.Contains(server.cnameRecords(), "alias.lan,"+tc.target)
+			if tc.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error for a CNAME to a missing local target")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "CNAME Target Not Found" {
+					t.Errorf("Expected 'CNAME Target Not Found', got '%s'", summary)
+				}
+				if created {
+					t.Errorf("Expected no CNAME to be created, got %v", server.cnameRecords())
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+			}
+			if !created {
+				t.Errorf("Expected the CNAME to be created, got %v", server.cnameRecords())
+			}
+		})
+	}
+}
+
+func TestIsExternalTarget(t *testing.T) {
+	testCases := []struct {
+		target        string
+		localSuffixes []string
+		expected      bool
+	}{
+		{"example.com", nil, true},
+		{"cdn.example.co.uk.", nil, true},
+		{"nas.lan", nil, false},
+		{"nas.local", nil, false},
+		{"nas", nil, false},
+		{"nas.home.arpa", nil, false},
+		{"nas.corp.example.com", []string{"corp.example.com"}, false},
+		{"www.example.com", []string{"corp.example.com"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			if result := isExternalTarget(tc.target, tc.localSuffixes); result != tc.expected {
+				t.Errorf("Expected isExternalTarget(%q, %v) to be %v, got %v", tc.target, tc.localSuffixes, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestCNAMERecordResource_UpdateWithUnchangedTargetSkipsWrites(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	r := NewCNAMERecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "www.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "www.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	}
+	resp := testResourceUpdate(t, r, state, state)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if writes := server.writeRequests(); len(writes) != 0 {
+		t.Errorf("Expected no delete or create for an unchanged record, got %v", writes)
+	}
+}
+
+func TestCNAMERecordResource_DeleteAbsentRecord(t *testing.T) {
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "www.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "www.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	}
+
+	testCases := []struct {
+		name   string
+		server func(t *testing.T) string
+	}{
+		{"not listed", func(t *testing.T) string {
+			fake := newFakePihole(map[string]map[string]interface{}{"dns": {"cnameRecords": []interface{}{}}})
+			t.Cleanup(fake.Close)
+			return fake.URL
+		}},
+		{"removed after listing", func(t *testing.T) string {
+			fake := createMockPiholeServer()
+			t.Cleanup(fake.Close)
+			return newVanishingRecordServer(t, fake).URL
+		}},
+		{"no records endpoint", func(t *testing.T) string {
+			fake := newFakePihole(map[string]map[string]interface{}{"dns": {"domain": "lan"}})
+			t.Cleanup(fake.Close)
+			return fake.URL
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewCNAMERecordResource()
+			testConfigureResource(t, r, newTestClient(t, tc.server(t)))
+
+			resp := testResourceDelete(t, r, state)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("Expected deleting an absent record to succeed, got %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestCNAMERecordResource_CreateAndReadTTL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ttl      tftypes.Value
+		expected string
+	}{
+		{"without ttl", tftypes.NewValue(tftypes.Number, nil), "blog.example.com,server.example.com"},
+		{"with ttl", tftypes.NewValue(tftypes.Number, 300), "blog.example.com,server.example.com,300"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := createMockPiholeServer()
+			defer server.Close()
+
+			r := NewCNAMERecordResource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			planned := map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "blog.example.com"),
+				"target": tftypes.NewValue(tftypes.String, "server.example.com"),
+				"ttl":    tc.ttl,
+			}
+			createResp := testResourceCreate(t, r, planned)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+			}
+
+			found := false
+			for _, record := range server.cnameRecords() {
+				if strings.HasPrefix(record, "blog.example.com,") {
+					found = true
+					if record != tc.expected {
+						t.Errorf("Expected stored record '%s', got '%s'", tc.expected, record)
+					}
+				}
+			}
+			if !found {
+				t.Fatalf("Expected the CNAME to be stored, got %v", server.cnameRecords())
+			}
+
+			planned["id"] = tftypes.NewValue(tftypes.String, "blog.example.com")
+			readResp := testResourceRead(t, r, planned)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+			}
+
+			var ttl types.Int64
+			readResp.State.GetAttribute(context.Background(), path.Root("ttl"), &ttl)
+			if tc.ttl.IsNull() {
+				if !ttl.IsNull() {
+					t.Errorf("Expected ttl to stay null, got %s", ttl)
+				}
+			} else if ttl.ValueInt64() != 300 {
+				t.Errorf("Expected ttl 300 to be read back, got %s", ttl)
+			}
+		})
+	}
+}
+
+func TestCNAMERecordResource_UpdateTTLRewritesRecord(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	r := NewCNAMERecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "www.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "www.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	}
+	planned := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "www.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "www.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+		"ttl":    tftypes.NewValue(tftypes.Number, 60),
+	}
+	resp := testResourceUpdate(t, r, state, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	records := server.cnameRecords()
+	for _, record := range records {
+		if record == "www.example.com,example.com" {
+			t.Errorf("Expected the record without TTL to be replaced, got %v", records)
+		}
+	}
+	found := false
+	for _, record := range records {
+		if record == "www.example.com,example.com,60" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected 'www.example.com,example.com,60' to be stored, got %v", records)
+	}
+}
+
+func TestCNAMERecordResource_ReadReflectsExternalTargetChange(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	r := NewCNAMERecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// www.example.com points to example.com in Terraform's state, but was changed outside of it
+	server.setValue("dns.cnameRecords", []string{"www.example.com,elsewhere.example.com"})
+
+	readResp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "www.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "www.example.com"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	})
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+	}
+
+	// Terraform compares the configured target against this and plans an update back
+	var target types.String
+	readResp.State.GetAttribute(context.Background(), path.Root("target"), &target)
+	if target.ValueString() != "elsewhere.example.com" {
+		t.Errorf("Expected read to report the external target so the plan restores it, got '%s'", target.ValueString())
+	}
+}
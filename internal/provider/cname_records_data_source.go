@@ -19,8 +19,9 @@ type CNAMERecordsDataSource struct {
 }
 
 type CNAMERecordsDataSourceModel struct {
-	ID      types.String                 `tfsdk:"id"`
-	Records []CNAMERecordDataSourceModel `tfsdk:"records"`
+	ID          types.String                 `tfsdk:"id"`
+	Records     []CNAMERecordDataSourceModel `tfsdk:"records"`
+	RecordCount types.Int64                  `tfsdk:"record_count"`
 }
 
 type CNAMERecordDataSourceModel struct {
@@ -41,6 +42,10 @@ func (d *CNAMERecordsDataSource) Schema(ctx context.Context, req datasource.Sche
 				MarkdownDescription: "Data source identifier",
 				Computed:            true,
 			},
+			"record_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of CNAME records",
+				Computed:            true,
+			},
 			"records": schema.ListNestedAttribute{
 				MarkdownDescription: "List of CNAME records",
 				Computed:            true,
@@ -88,11 +93,12 @@ func (d *CNAMERecordsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	// Get CNAME records from Pi-hole
-	records, err := d.client.GetCNAMERecords()
+	snapshot, err := d.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", "Unable to read CNAME records: "+err.Error())
 		return
 	}
+	records := snapshot.CNAMERecords
 
 	// Convert to data source model
 	recordModels := make([]CNAMERecordDataSourceModel, 0, len(records))
@@ -104,6 +110,7 @@ func (d *CNAMERecordsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	data.ID = types.StringValue("cname_records")
+	data.RecordCount = types.Int64Value(int64(len(records)))
 	data.Records = recordModels
 
 	// Save data into Terraform state
@@ -94,24 +94,7 @@ func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Convert the value to string
-	var valueStr string
-	switch v := configSetting.Value.(type) {
-	case bool:
-		if v {
-			valueStr = "true"
-		} else {
-			valueStr = "false"
-		}
-	case string:
-		valueStr = v
-	case float64:
-		valueStr = fmt.Sprintf("%.0f", v)
-	default:
-		valueStr = fmt.Sprintf("%v", v)
-	}
-
-	data.Value = types.StringValue(valueStr)
+	data.Value = types.StringValue(configValueToString(configSetting.Value))
 	data.ID = types.StringValue(key)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
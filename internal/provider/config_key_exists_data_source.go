@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigKeyExistsDataSource{}
+
+func NewConfigKeyExistsDataSource() datasource.DataSource {
+	return &ConfigKeyExistsDataSource{}
+}
+
+type ConfigKeyExistsDataSource struct {
+	client *PiholeClient
+}
+
+type ConfigKeyExistsDataSourceModel struct {
+	Key    types.String `tfsdk:"key"`
+	Exists types.Bool   `tfsdk:"exists"`
+	Value  types.String `tfsdk:"value"`
+	ID     types.String `tfsdk:"id"`
+}
+
+func (d *ConfigKeyExistsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_key_exists"
+}
+
+func (d *ConfigKeyExistsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a configuration key exists in Pi-hole. Unlike `pihole_config`, a missing key " +
+			"doesn't fail the plan, so settings that only exist in some Pi-hole versions can be managed conditionally.",
+
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Configuration key to check (e.g., 'webserver.api.app_sudo'), in dot notation",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key exists in Pi-hole",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Current value of the key, null if it doesn't exist. Boolean values are returned as 'true' or 'false'.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier (same as key)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ConfigKeyExistsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ConfigKeyExistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigKeyExistsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := data.Key.ValueString()
+	data.ID = types.StringValue(key)
+
+	configSetting, err := d.client.GetConfig(key)
+	if errors.Is(err, ErrConfigKeyNotFound) {
+		data.Exists = types.BoolValue(false)
+		data.Value = types.StringNull()
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Pi-hole Configuration",
+			fmt.Sprintf("Could not check configuration setting '%s': %s", key, err.Error()),
+		)
+		return
+	}
+
+	data.Exists = types.BoolValue(true)
+	data.Value = types.StringValue(configValueToString(configSetting.Value))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConfigKeyExistsDataSource_Read(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"api": map[string]interface{}{"app_sudo": true}},
+		"dns":       {"domain": "lan"},
+	})
+	defer server.Close()
+
+	testCases := []struct {
+		key            string
+		expectedExists bool
+		expectedValue  string
+	}{
+		{"webserver.api.app_sudo", true, "true"},
+		{"dns.domain", true, "lan"},
+		{"webserver.api.renamed_key", false, ""},
+		{"dns.removed_key", false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			d := NewConfigKeyExistsDataSource()
+			d.(*ConfigKeyExistsDataSource).client = newTestClient(t, server.URL)
+
+			resp := testDataSourceRead(t, d, map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, tc.key),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			var data ConfigKeyExistsDataSourceModel
+			resp.State.Get(context.Background(), &data)
+
+			if data.Exists.ValueBool() != tc.expectedExists {
+				t.Errorf("Expected exists %t, got %s", tc.expectedExists, data.Exists)
+			}
+			if tc.expectedExists && data.Value.ValueString() != tc.expectedValue {
+				t.Errorf("Expected value '%s', got %s", tc.expectedValue, data.Value)
+			}
+			if !tc.expectedExists && !data.Value.IsNull() {
+				t.Errorf("Expected a null value for a missing key, got %s", data.Value)
+			}
+		})
+	}
+}
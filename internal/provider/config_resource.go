@@ -2,14 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -26,9 +31,13 @@ type ConfigResource struct {
 }
 
 type ConfigResourceModel struct {
-	Key   types.String `tfsdk:"key"`
-	Value types.String `tfsdk:"value"`
-	ID    types.String `tfsdk:"id"`
+	Key             types.String `tfsdk:"key"`
+	Value           types.String `tfsdk:"value"`
+	RemoveOnMissing types.Bool   `tfsdk:"remove_on_missing"`
+	RestartOnChange types.Bool   `tfsdk:"restart_on_change"`
+	RequestDelayMs  types.Int64  `tfsdk:"request_delay_ms"`
+	Type            types.String `tfsdk:"type"`
+	ID              types.String `tfsdk:"id"`
 }
 
 func (r *ConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,6 +65,38 @@ func (r *ConfigResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Configuration value. For boolean settings, use 'true' or 'false'.",
 				Required:            true,
 			},
+			"remove_on_missing": schema.BoolAttribute{
+				MarkdownDescription: "What to do when the configuration key no longer exists in Pi-hole, e.g. after it was " +
+					"renamed or removed in a Pi-hole upgrade. When `true`, the resource is removed from state and will be " +
+					"recreated on the next apply. When `false` (default), a warning is emitted and the value is set to null.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"restart_on_change": schema.BoolAttribute{
+				MarkdownDescription: "Whether to restart FTL's DNS resolver after `value` is written. Set this for keys " +
+					"Pi-hole only reads when the resolver starts. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"request_delay_ms": schema.Int64Attribute{
+				MarkdownDescription: "Delay in milliseconds before each API call of this resource, overriding the provider's " +
+					"`request_delay_ms`, e.g. to pace configuration writes more slowly than DNS record changes. " +
+					"Defaults to the provider setting.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "JSON type Pi-hole stores the value as: `bool`, `number`, `string`, `list` or `object`. " +
+					"`value` is converted to this type when it is written.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Resource identifier (same as key)",
 				Computed:            true,
@@ -98,16 +139,10 @@ func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest,
 	key := data.Key.ValueString()
 	value := data.Value.ValueString()
 
-	// Convert string value to appropriate type for boolean settings
-	var configValue interface{} = value
-	if strings.ToLower(value) == "true" {
-		configValue = true
-	} else if strings.ToLower(value) == "false" {
-		configValue = false
-	}
+	opts := requestDelayOptions(data.RequestDelayMs)
 
-	// Set the configuration using the client
-	err := r.client.SetConfig(key, configValue)
+	// The client converts the string to the type Pi-hole currently stores for the key
+	err := r.client.SetConfig(key, value, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Pi-hole Configuration",
@@ -116,8 +151,28 @@ func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	if data.RestartOnChange.ValueBool() {
+		if err := r.client.RestartDNS(opts...); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Restarting Pi-hole DNS",
+				fmt.Sprintf("Configuration setting '%s' was written, but the DNS resolver could not be restarted: %s", key, err.Error()),
+			)
+			return
+		}
+	}
+
+	configSetting, err := r.client.GetConfig(key, opts...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Pi-hole Configuration",
+			fmt.Sprintf("Could not read configuration setting '%s' after creating it: %s", key, err.Error()),
+		)
+		return
+	}
+
 	// Set the ID to the key
 	data.ID = data.Key
+	data.Type = types.StringValue(configValueType(configSetting.Value))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -133,8 +188,34 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	key := data.Key.ValueString()
 
+	// Imported resources don't have the attributes set yet
+	if data.RemoveOnMissing.IsNull() {
+		data.RemoveOnMissing = types.BoolValue(false)
+	}
+	if data.RestartOnChange.IsNull() {
+		data.RestartOnChange = types.BoolValue(false)
+	}
+
 	// Get current configuration value
-	configSetting, err := r.client.GetConfig(key)
+	configSetting, err := r.client.GetConfig(key, requestDelayOptions(data.RequestDelayMs)...)
+	if errors.Is(err, ErrConfigKeyNotFound) {
+		// A vanished key must not block all other operations
+		if data.RemoveOnMissing.ValueBool() {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Pi-hole Configuration Key Not Found",
+			fmt.Sprintf("Configuration setting '%s' no longer exists in Pi-hole. It may have been renamed or removed in a Pi-hole upgrade.", key),
+		)
+		data.Value = types.StringNull()
+		data.Type = types.StringNull()
+		data.ID = data.Key
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Pi-hole Configuration",
@@ -143,24 +224,8 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Convert the value back to string
-	var valueStr string
-	switch v := configSetting.Value.(type) {
-	case bool:
-		if v {
-			valueStr = "true"
-		} else {
-			valueStr = "false"
-		}
-	case string:
-		valueStr = v
-	case float64:
-		valueStr = fmt.Sprintf("%.0f", v)
-	default:
-		valueStr = fmt.Sprintf("%v", v)
-	}
-
-	data.Value = types.StringValue(valueStr)
+	data.Value = types.StringValue(configValueToString(configSetting.Value))
+	data.Type = types.StringValue(configValueType(configSetting.Value))
 	data.ID = data.Key
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -175,19 +240,21 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	var state ConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	key := data.Key.ValueString()
 	value := data.Value.ValueString()
 
-	// Convert string value to appropriate type for boolean settings
-	var configValue interface{} = value
-	if strings.ToLower(value) == "true" {
-		configValue = true
-	} else if strings.ToLower(value) == "false" {
-		configValue = false
-	}
+	opts := requestDelayOptions(data.RequestDelayMs)
 
-	// Update the configuration using the client
-	err := r.client.SetConfig(key, configValue)
+	// The client converts the string to the type Pi-hole currently stores for the key
+	err := r.client.SetConfig(key, value, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Pi-hole Configuration",
@@ -196,6 +263,17 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Toggling only the flags leaves the value in effect as it is
+	if data.RestartOnChange.ValueBool() && !data.Value.Equal(state.Value) {
+		if err := r.client.RestartDNS(opts...); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Restarting Pi-hole DNS",
+				fmt.Sprintf("Configuration setting '%s' was updated, but the DNS resolver could not be restarted: %s", key, err.Error()),
+			)
+			return
+		}
+	}
+
 	data.ID = data.Key
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -219,7 +297,22 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		defaultValue = false
 	}
 
-	err := r.client.SetConfig(key, defaultValue)
+	opts := requestDelayOptions(data.RequestDelayMs)
+
+	// Writing the default would create a key that no longer exists, so a vanished key is already gone
+	_, err := r.client.GetConfig(key, opts...)
+	if errors.Is(err, ErrConfigKeyNotFound) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Pi-hole Configuration",
+			fmt.Sprintf("Could not read configuration setting '%s': %s", key, err.Error()),
+		)
+		return
+	}
+
+	err = r.client.SetConfig(key, defaultValue, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Pi-hole Configuration",
@@ -230,5 +323,84 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("key"), req, resp)
+	// The key is read right away, so the imported state has the value and its type without waiting for a refresh
+	configSetting, err := r.client.GetConfig(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Pi-hole Configuration",
+			fmt.Sprintf("Could not read configuration setting '%s': %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	data := ConfigResourceModel{
+		Key:             types.StringValue(req.ID),
+		Value:           types.StringValue(configValueToString(configSetting.Value)),
+		RemoveOnMissing: types.BoolValue(false),
+		RestartOnChange: types.BoolValue(false),
+		Type:            types.StringValue(configValueType(configSetting.Value)),
+		ID:              types.StringValue(req.ID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// configValueType names the JSON type of a configuration value returned by Pi-hole
+func configValueType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// requestDelayOptions overrides the provider's request delay for the calls of a resource that sets request_delay_ms
+func requestDelayOptions(requestDelayMs types.Int64) []RequestOption {
+	if requestDelayMs.IsNull() || requestDelayMs.IsUnknown() {
+		return nil
+	}
+	return []RequestOption{WithRequestDelay(requestDelayMs.ValueInt64())}
+}
+
+// configValueToString converts a configuration value returned by Pi-hole to its string representation.
+// Depending on the key, Pi-hole returns booleans and numbers as strings, which are normalized to the
+// representation of the typed value, so "True" and true both read as "true".
+func configValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case string:
+		if strings.EqualFold(v, "true") || strings.EqualFold(v, "false") {
+			return strings.ToLower(v)
+		}
+		if trimmed := strings.TrimSpace(v); trimmed != v {
+			if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+				return trimmed
+			}
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		// Arrays are encoded as JSON so they can be converted back when written
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
 }
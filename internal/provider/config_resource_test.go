@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"context"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestConfigResource_Schema(t *testing.T) {
@@ -118,3 +126,359 @@ func TestConfigResource_ValueToStringConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigResource_ReadRemovesVanishedKey(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"api": map[string]interface{}{"app_sudo": true}},
+	})
+	defer server.Close()
+
+	r := NewConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"key":               tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"value":             tftypes.NewValue(tftypes.String, "true"),
+		"remove_on_missing": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Error("Expected the resource to be removed from state when its key vanished")
+	}
+}
+
+func TestConfigResource_ReadWarnsOnVanishedKey(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"api": map[string]interface{}{"app_sudo": true}},
+	})
+	defer server.Close()
+
+	r := NewConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"key":               tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"value":             tftypes.NewValue(tftypes.String, "true"),
+		"remove_on_missing": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Expected one warning, got %d", resp.Diagnostics.WarningsCount())
+	}
+
+	var value types.String
+	resp.State.GetAttribute(context.Background(), path.Root("value"), &value)
+	if !value.IsNull() {
+		t.Errorf("Expected value to be null, got '%s'", value.ValueString())
+	}
+}
+
+func TestConfigValueToString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"bool true", true, "true"},
+		{"bool false", false, "false"},
+		{"bool as string", "True", "true"},
+		{"bool as upper case string", "FALSE", "false"},
+		{"integer", float64(300), "300"},
+		{"fraction", 1.5, "1.5"},
+		{"integer as string", "300", "300"},
+		{"integer as padded string", " 300 ", "300"},
+		{"fraction as string", "1.5", "1.5"},
+		{"plain string", "80o,443os", "80o,443os"},
+		{"padded plain string", " lan ", " lan "},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := configValueToString(tc.input); result != tc.expected {
+				t.Errorf("For %#v: expected '%s', got '%s'", tc.input, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestConfigResource_ValueRoundTripsStoredType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		key      string
+		stored   interface{}
+		value    string
+		expected interface{}
+	}{
+		{"genuine bool", "webserver.api.app_sudo", false, "TRUE", true},
+		{"bool stored as string", "webserver.api.localAPIauth", "false", "true", "true"},
+		{"genuine number", "webserver.session.timeout", float64(1800), "3600", float64(3600)},
+		{"fractional number", "webserver.api.temp_limit", 60.5, "62.5", 62.5},
+		{"number stored as string", "webserver.threads", "50", "100", "100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keyParts := strings.Split(strings.TrimPrefix(tc.key, "webserver."), ".")
+			webserver := map[string]interface{}{}
+			setNestedConfigValue(webserver, keyParts, tc.stored)
+
+			server := newFakePihole(map[string]map[string]interface{}{"webserver": webserver})
+			defer server.Close()
+
+			r := NewConfigResource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			createResp := testResourceCreate(t, r, map[string]tftypes.Value{
+				"key":               tftypes.NewValue(tftypes.String, tc.key),
+				"value":             tftypes.NewValue(tftypes.String, tc.value),
+				"remove_on_missing": tftypes.NewValue(tftypes.Bool, false),
+			})
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+			}
+
+			if stored := lookupNestedConfigValue(server.section("webserver"), keyParts); stored != tc.expected {
+				t.Errorf("Expected Pi-hole to store %#v, got %#v", tc.expected, stored)
+			}
+
+			readResp := testResourceRead(t, r, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, tc.key),
+				"key":               tftypes.NewValue(tftypes.String, tc.key),
+				"value":             tftypes.NewValue(tftypes.String, tc.value),
+				"remove_on_missing": tftypes.NewValue(tftypes.Bool, false),
+			})
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+			}
+
+			var value types.String
+			readResp.State.GetAttribute(context.Background(), path.Root("value"), &value)
+			if value.ValueString() != strings.ToLower(tc.value) {
+				t.Errorf("Expected value '%s' to read back unchanged, got '%s'", strings.ToLower(tc.value), value.ValueString())
+			}
+		})
+	}
+}
+
+func TestConfigResource_RejectsValueOfWrongType(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"session": map[string]interface{}{"timeout": 1800}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.SetConfig("webserver.session.timeout", "half an hour"); err == nil {
+		t.Error("Expected a non-numeric value for a numeric key to be rejected")
+	}
+}
+
+func TestConfigResource_RequestDelayOverride(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns":       {"hosts": []string{}},
+		"webserver": {"session": map[string]interface{}{"timeout": 1800}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.Config.RequestDelayMs = 50
+
+	// Record the delays instead of waiting for them
+	var mu sync.Mutex
+	var sleeps []time.Duration
+	client.sleepFunc = func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, d)
+	}
+	recorded := func() []time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded := sleeps
+		sleeps = nil
+		return recorded
+	}
+
+	configResource := NewConfigResource()
+	testConfigureResource(t, configResource, client)
+	resp := testResourceCreate(t, configResource, map[string]tftypes.Value{
+		"key":              tftypes.NewValue(tftypes.String, "webserver.session.timeout"),
+		"value":            tftypes.NewValue(tftypes.String, "3600"),
+		"request_delay_ms": tftypes.NewValue(tftypes.Number, 500),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	configSleeps := recorded()
+	if len(configSleeps) == 0 {
+		t.Fatal("Expected the config write to be delayed")
+	}
+	for _, d := range configSleeps {
+		if d != 500*time.Millisecond {
+			t.Errorf("Expected the config write to use the override of 500ms, got delays %v", configSleeps)
+			break
+		}
+	}
+
+	dnsResource := NewDNSRecordResource()
+	testConfigureResource(t, dnsResource, client)
+	dnsResp := testResourceCreate(t, dnsResource, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if dnsResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", dnsResp.Diagnostics.Errors())
+	}
+
+	dnsSleeps := recorded()
+	if len(dnsSleeps) == 0 {
+		t.Fatal("Expected the DNS record write to be delayed")
+	}
+	for _, d := range dnsSleeps {
+		if d != 50*time.Millisecond {
+			t.Errorf("Expected the DNS record write to use the global delay of 50ms, got delays %v", dnsSleeps)
+			break
+		}
+	}
+}
+
+func TestConfigResource_ImportInfersType(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api":     map[string]interface{}{"app_sudo": true, "localAPIauth": "false"},
+			"session": map[string]interface{}{"timeout": float64(1800)},
+		},
+	})
+	defer server.Close()
+
+	r := NewConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	testCases := []struct {
+		key       string
+		value     string
+		valueType string
+		expectErr bool
+	}{
+		{"webserver.api.app_sudo", "true", "bool", false},
+		{"webserver.session.timeout", "1800", "number", false},
+		{"webserver.api.localAPIauth", "false", "string", false},
+		{"webserver.api.missing_key", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			schemaResp, raw := testResourceObject(t, r, nil)
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+			}
+			r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: tc.key}, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, resp.Diagnostics.Errors())
+			}
+			if tc.expectErr {
+				return
+			}
+
+			var key, value, valueType types.String
+			resp.State.GetAttribute(context.Background(), path.Root("key"), &key)
+			resp.State.GetAttribute(context.Background(), path.Root("value"), &value)
+			resp.State.GetAttribute(context.Background(), path.Root("type"), &valueType)
+			if key.ValueString() != tc.key {
+				t.Errorf("Expected key '%s', got '%s'", tc.key, key.ValueString())
+			}
+			if value.ValueString() != tc.value {
+				t.Errorf("Expected value '%s', got '%s'", tc.value, value.ValueString())
+			}
+			if valueType.ValueString() != tc.valueType {
+				t.Errorf("Expected type '%s', got '%s'", tc.valueType, valueType.ValueString())
+			}
+		})
+	}
+}
+
+func TestConfigResource_DeleteVanishedKey(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"api": map[string]interface{}{"app_sudo": true}},
+	})
+	defer server.Close()
+
+	r := NewConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"key":   tftypes.NewValue(tftypes.String, "webserver.api.renamed_key"),
+		"value": tftypes.NewValue(tftypes.String, "true"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected deleting a vanished key to succeed, got %v", resp.Diagnostics.Errors())
+	}
+
+	// Resetting the key would bring it back
+	if writes := server.writeRequests(); len(writes) != 0 {
+		t.Errorf("Expected no writes for a vanished key, got %v", writes)
+	}
+}
+
+func TestConfigResource_RestartOnChange(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {"session": map[string]interface{}{"timeout": 1800}},
+	})
+	defer server.Close()
+
+	r := NewConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	config := func(value string, restart bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":                tftypes.NewValue(tftypes.String, "webserver.session.timeout"),
+			"key":               tftypes.NewValue(tftypes.String, "webserver.session.timeout"),
+			"value":             tftypes.NewValue(tftypes.String, value),
+			"remove_on_missing": tftypes.NewValue(tftypes.Bool, false),
+			"restart_on_change": tftypes.NewValue(tftypes.Bool, restart),
+			"type":              tftypes.NewValue(tftypes.String, "number"),
+		}
+	}
+	restarts := func() int {
+		return server.requestCount("POST /api/action/restartdns")
+	}
+
+	if resp := testResourceCreate(t, r, config("3600", false)); resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+	if restarts() != 0 {
+		t.Errorf("Expected no restart without restart_on_change, got %d", restarts())
+	}
+
+	if resp := testResourceCreate(t, r, config("3600", true)); resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+	if restarts() != 1 {
+		t.Errorf("Expected a restart after creating with restart_on_change, got %d", restarts())
+	}
+
+	// Turning the flag on alone doesn't change the value, so there is nothing to restart for
+	if resp := testResourceUpdate(t, r, config("3600", false), config("3600", true)); resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+	if restarts() != 1 {
+		t.Errorf("Expected no restart for an unchanged value, got %d", restarts())
+	}
+
+	if resp := testResourceUpdate(t, r, config("3600", true), config("7200", true)); resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+	if restarts() != 2 {
+		t.Errorf("Expected a restart after changing the value, got %d", restarts())
+	}
+}
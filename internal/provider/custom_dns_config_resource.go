@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CustomDNSConfigResource{}
+
+func NewCustomDNSConfigResource() resource.Resource {
+	return &CustomDNSConfigResource{}
+}
+
+type CustomDNSConfigResource struct {
+	client *PiholeClient
+}
+
+type CustomDNSConfigResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Lines types.List   `tfsdk:"lines"`
+}
+
+func (r *CustomDNSConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_dns_config"
+}
+
+func (r *CustomDNSConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages additional dnsmasq directives Pi-hole passes to its embedded DNS server (`misc.dnsmasq_lines`). " +
+			"**Warning**: The lines are handed to dnsmasq as they are. An invalid or conflicting directive can stop Pi-hole " +
+			"from resolving DNS queries. Deleting this resource removes all custom lines.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Custom DNS configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"lines": schema.ListAttribute{
+				MarkdownDescription: "dnsmasq directives, one per entry and in the order they are written (e.g. `address=/example.test/10.0.0.1`)",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^\r\n]*$`), "must be a single line"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *CustomDNSConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CustomDNSConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CustomDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var lines []string
+	resp.Diagnostics.Append(data.Lines.ElementsAs(ctx, &lines, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("misc", customDNSConfigValues(lines)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set custom dnsmasq lines, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom dnsmasq lines, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomDNSConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CustomDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom dnsmasq lines, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomDNSConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CustomDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var lines []string
+	resp.Diagnostics.Append(data.Lines.ElementsAs(ctx, &lines, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("misc", customDNSConfigValues(lines)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update custom dnsmasq lines, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom dnsmasq lines, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomDNSConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Pi-hole ships without custom lines, so removing all of them restores the default
+	if err := r.client.SetConfigSection("misc", customDNSConfigValues([]string{})); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove custom dnsmasq lines, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the custom dnsmasq lines currently configured in Pi-hole, keeping their order
+func (r *CustomDNSConfigResource) readInto(ctx context.Context, data *CustomDNSConfigResourceModel) error {
+	miscConfig, err := r.client.GetConfigSection("misc")
+	if err != nil {
+		return err
+	}
+
+	rawLines, ok := miscConfig["dnsmasq_lines"].([]interface{})
+	if !ok && miscConfig["dnsmasq_lines"] != nil {
+		return fmt.Errorf("unexpected value for misc.dnsmasq_lines: %v", miscConfig["dnsmasq_lines"])
+	}
+
+	lines := make([]string, 0, len(rawLines))
+	for _, rawLine := range rawLines {
+		line, ok := rawLine.(string)
+		if !ok {
+			return fmt.Errorf("unexpected entry in misc.dnsmasq_lines: %v", rawLine)
+		}
+		lines = append(lines, line)
+	}
+
+	linesValue, diags := types.ListValueFrom(ctx, types.StringType, lines)
+	if diags.HasError() {
+		return fmt.Errorf("unable to convert dnsmasq lines: %v", diags.Errors())
+	}
+
+	data.ID = types.StringValue("custom_dns_config")
+	data.Lines = linesValue
+
+	return nil
+}
+
+// customDNSConfigValues builds the misc section payload for the given dnsmasq lines
+func customDNSConfigValues(lines []string) map[string]interface{} {
+	return map[string]interface{}{
+		"dnsmasq_lines": lines,
+	}
+}
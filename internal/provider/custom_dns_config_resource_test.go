@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// customDNSConfigFakeSections is a misc section with custom dnsmasq lines next to unrelated settings
+func customDNSConfigFakeSections(lines ...string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"misc": {
+			"nice":          -10,
+			"dnsmasq_lines": lines,
+		},
+	}
+}
+
+func testDnsmasqLinesValue(lines ...string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(lines))
+	for _, line := range lines {
+		values = append(values, tftypes.NewValue(tftypes.String, line))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+}
+
+func TestCustomDNSConfigResource_Metadata(t *testing.T) {
+	r := NewCustomDNSConfigResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_custom_dns_config" {
+		t.Errorf("Expected TypeName to be 'pihole_custom_dns_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestCustomDNSConfigResource_WritesAndReadsLines(t *testing.T) {
+	server := newFakePihole(customDNSConfigFakeSections())
+	defer server.Close()
+
+	r := NewCustomDNSConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// The order deliberately isn't sorted, it has to survive the round trip
+	lines := []string{"server=/corp.example/10.0.0.53", "address=/blocked.example/0.0.0.0"}
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"lines": testDnsmasqLinesValue(lines...),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	miscConfig := server.section("misc")
+	stored, _ := miscConfig["dnsmasq_lines"].([]interface{})
+	if len(stored) != 2 || stored[0] != lines[0] || stored[1] != lines[1] {
+		t.Errorf("Expected misc.dnsmasq_lines to be %v, got %v", lines, miscConfig["dnsmasq_lines"])
+	}
+	// Unrelated misc settings must survive the write
+	if miscConfig["nice"] != float64(-10) {
+		t.Errorf("Expected unrelated misc.nice to be preserved, got %v", miscConfig["nice"])
+	}
+
+	readResp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "custom_dns_config"),
+		"lines": testDnsmasqLinesValue(lines...),
+	})
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+	}
+
+	var readLines types.List
+	readResp.State.GetAttribute(context.Background(), path.Root("lines"), &readLines)
+	var got []string
+	readLines.ElementsAs(context.Background(), &got, false)
+	if !reflect.DeepEqual(got, lines) {
+		t.Errorf("Expected lines %v to be read back in order, got %v", lines, got)
+	}
+}
+
+func TestCustomDNSConfigResource_LinesValidation(t *testing.T) {
+	r := NewCustomDNSConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	linesAttr := schemaResp.Schema.Attributes["lines"].(schema.ListAttribute)
+
+	testCases := []struct {
+		name      string
+		lines     []string
+		expectErr bool
+	}{
+		{"single directive", []string{"server=/corp.example/10.0.0.53"}, false},
+		{"empty list", []string{}, false},
+		{"empty entry", []string{""}, true},
+		{"two lines in one entry", []string{"server=/a.example/10.0.0.1\nserver=/b.example/10.0.0.2"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, _ := types.ListValueFrom(context.Background(), types.StringType, tc.lines)
+			req := validator.ListRequest{
+				Path:        path.Root("lines"),
+				ConfigValue: value,
+			}
+			resp := &validator.ListResponse{}
+			for _, v := range linesAttr.Validators {
+				v.ValidateList(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For %v: expected error %v, got %v", tc.lines, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestCustomDNSConfigResource_DeleteRemovesLines(t *testing.T) {
+	server := newFakePihole(customDNSConfigFakeSections("address=/blocked.example/0.0.0.0"))
+	defer server.Close()
+
+	r := NewCustomDNSConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "custom_dns_config"),
+		"lines": testDnsmasqLinesValue("address=/blocked.example/0.0.0.0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if stored, _ := server.section("misc")["dnsmasq_lines"].([]interface{}); len(stored) != 0 {
+		t.Errorf("Expected misc.dnsmasq_lines to be empty, got %v", server.section("misc")["dnsmasq_lines"])
+	}
+}
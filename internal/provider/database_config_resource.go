@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseConfigResource{}
+
+// Pi-hole's defaults for the database section, restored when the resource is deleted
+const (
+	defaultMaxDBDays  = 91
+	defaultDBInterval = 60
+)
+
+// maxDBDaysLimit is the largest retention FTL accepts, as the retention in seconds must fit into an int32
+const maxDBDaysLimit = 24855
+
+func NewDatabaseConfigResource() resource.Resource {
+	return &DatabaseConfigResource{}
+}
+
+type DatabaseConfigResource struct {
+	client *PiholeClient
+}
+
+type DatabaseConfigResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	MaxDBDays  types.Int64  `tfsdk:"max_db_days"`
+	DBInterval types.Int64  `tfsdk:"db_interval"`
+}
+
+func (r *DatabaseConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_config"
+}
+
+func (r *DatabaseConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages how long FTL keeps the query history in its long-term database " +
+			"(`database.maxDBdays` and `database.DBinterval`). " +
+			"Deleting this resource restores Pi-hole's defaults of 91 days retention and a 60 second store interval.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Database configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_db_days": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of days queries are kept in the database. "+
+					"`-1` keeps them forever, `0` disables storing queries. At most `%d`.", maxDBDaysLimit),
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.Between(-1, maxDBDaysLimit),
+				},
+			},
+			"db_interval": schema.Int64Attribute{
+				MarkdownDescription: "Interval in seconds in which new queries are written to the database",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *DatabaseConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DatabaseConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatabaseConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("database", databaseConfigValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set database configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatabaseConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatabaseConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("database", databaseConfigValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update database configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.client.SetConfigSection("database", map[string]interface{}{
+		"maxDBdays":  defaultMaxDBDays,
+		"DBinterval": defaultDBInterval,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset database configuration, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the database configuration currently active in Pi-hole
+func (r *DatabaseConfigResource) readInto(data *DatabaseConfigResourceModel) error {
+	databaseConfig, err := r.client.GetConfigSection("database")
+	if err != nil {
+		return err
+	}
+
+	maxDBDays, ok := databaseConfig["maxDBdays"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for database.maxDBdays: %v", databaseConfig["maxDBdays"])
+	}
+	dbInterval, ok := databaseConfig["DBinterval"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for database.DBinterval: %v", databaseConfig["DBinterval"])
+	}
+
+	data.ID = types.StringValue("database_config")
+	data.MaxDBDays = types.Int64Value(int64(maxDBDays))
+	data.DBInterval = types.Int64Value(int64(dbInterval))
+
+	return nil
+}
+
+// databaseConfigValues builds the database section payload from the planned model
+func databaseConfigValues(data DatabaseConfigResourceModel) map[string]interface{} {
+	values := map[string]interface{}{
+		"maxDBdays": data.MaxDBDays.ValueInt64(),
+	}
+	if !data.DBInterval.IsNull() && !data.DBInterval.IsUnknown() {
+		values["DBinterval"] = data.DBInterval.ValueInt64()
+	}
+	return values
+}
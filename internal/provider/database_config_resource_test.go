@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// databaseFakeSections is a database section with the retention next to unrelated settings
+func databaseFakeSections(maxDBDays, dbInterval int) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"database": {
+			"DBimport":   true,
+			"maxDBdays":  maxDBDays,
+			"DBinterval": dbInterval,
+		},
+	}
+}
+
+func TestDatabaseConfigResource_Schema(t *testing.T) {
+	r := NewDatabaseConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	maxDBDaysAttr, exists := schemaResp.Schema.Attributes["max_db_days"]
+	if !exists {
+		t.Error("Schema should have 'max_db_days' attribute")
+	} else if !maxDBDaysAttr.IsRequired() {
+		t.Error("'max_db_days' attribute should be required")
+	}
+
+	dbIntervalAttr, exists := schemaResp.Schema.Attributes["db_interval"]
+	if !exists {
+		t.Error("Schema should have 'db_interval' attribute")
+	} else if !dbIntervalAttr.IsOptional() {
+		t.Error("'db_interval' attribute should be optional")
+	}
+}
+
+func TestDatabaseConfigResource_Metadata(t *testing.T) {
+	r := NewDatabaseConfigResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_database_config" {
+		t.Errorf("Expected TypeName to be 'pihole_database_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestDatabaseConfigResource_Validation(t *testing.T) {
+	r := NewDatabaseConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	testCases := []struct {
+		attribute string
+		value     int64
+		expectErr bool
+	}{
+		{"max_db_days", 30, false},
+		{"max_db_days", 0, false},
+		{"max_db_days", -1, false},
+		{"max_db_days", -2, true},
+		{"max_db_days", 24855, false},
+		{"max_db_days", 24856, true},
+		{"db_interval", 60, false},
+		{"db_interval", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s=%d", tc.attribute, tc.value), func(t *testing.T) {
+			attr := schemaResp.Schema.Attributes[tc.attribute].(schema.Int64Attribute)
+			req := validator.Int64Request{
+				Path:        path.Root(tc.attribute),
+				ConfigValue: types.Int64Value(tc.value),
+			}
+			resp := &validator.Int64Response{}
+			for _, v := range attr.Validators {
+				v.ValidateInt64(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For %s=%d: expected error %v, got %v", tc.attribute, tc.value, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestDatabaseConfigResource_SetRetention(t *testing.T) {
+	server := newFakePihole(databaseFakeSections(91, 60))
+	defer server.Close()
+
+	r := NewDatabaseConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"max_db_days": tftypes.NewValue(tftypes.Number, 30),
+		"db_interval": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	databaseConfig := server.section("database")
+	if databaseConfig["maxDBdays"] != 30.0 {
+		t.Errorf("Expected database.maxDBdays to be 30, got %v", databaseConfig["maxDBdays"])
+	}
+	// Unset and unrelated database settings must be left untouched by the PATCH
+	if databaseConfig["DBinterval"] != 60.0 || databaseConfig["DBimport"] != true {
+		t.Errorf("Expected other database settings to be preserved, got %v", databaseConfig)
+	}
+
+	var maxDBDays, dbInterval types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("max_db_days"), &maxDBDays)
+	resp.State.GetAttribute(context.Background(), path.Root("db_interval"), &dbInterval)
+	if maxDBDays.ValueInt64() != 30 || dbInterval.ValueInt64() != 60 {
+		t.Errorf("Expected state 30/60, got %s/%s", maxDBDays, dbInterval)
+	}
+}
+
+func TestDatabaseConfigResource_DisableStorage(t *testing.T) {
+	server := newFakePihole(databaseFakeSections(30, 60))
+	defer server.Close()
+
+	r := NewDatabaseConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	prior := map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "database_config"),
+		"max_db_days": tftypes.NewValue(tftypes.Number, 30),
+		"db_interval": tftypes.NewValue(tftypes.Number, 60),
+	}
+	resp := testResourceUpdate(t, r, prior, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "database_config"),
+		"max_db_days": tftypes.NewValue(tftypes.Number, 0),
+		"db_interval": tftypes.NewValue(tftypes.Number, 60),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if maxDBDays := server.section("database")["maxDBdays"]; maxDBDays != 0.0 {
+		t.Errorf("Expected query storage to be disabled (maxDBdays 0), got %v", maxDBDays)
+	}
+
+	var maxDBDays types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("max_db_days"), &maxDBDays)
+	if maxDBDays.IsNull() || maxDBDays.ValueInt64() != 0 {
+		t.Errorf("Expected max_db_days 0 in state, got %s", maxDBDays)
+	}
+}
+
+func TestDatabaseConfigResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(databaseFakeSections(-1, 300))
+	defer server.Close()
+
+	r := NewDatabaseConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "database_config"),
+		"max_db_days": tftypes.NewValue(tftypes.Number, 30),
+		"db_interval": tftypes.NewValue(tftypes.Number, 60),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var maxDBDays, dbInterval types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("max_db_days"), &maxDBDays)
+	resp.State.GetAttribute(context.Background(), path.Root("db_interval"), &dbInterval)
+	if maxDBDays.ValueInt64() != -1 || dbInterval.ValueInt64() != 300 {
+		t.Errorf("Expected state to reflect Pi-hole (-1/300), got %s/%s", maxDBDays, dbInterval)
+	}
+}
+
+func TestDatabaseConfigResource_DeleteRestoresDefaults(t *testing.T) {
+	server := newFakePihole(databaseFakeSections(0, 300))
+	defer server.Close()
+
+	r := NewDatabaseConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "database_config"),
+		"max_db_days": tftypes.NewValue(tftypes.Number, 0),
+		"db_interval": tftypes.NewValue(tftypes.Number, 300),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	databaseConfig := server.section("database")
+	if databaseConfig["maxDBdays"] != 91.0 || databaseConfig["DBinterval"] != 60.0 {
+		t.Errorf("Expected database configuration to be reset to 91/60, got %v/%v", databaseConfig["maxDBdays"], databaseConfig["DBinterval"])
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DHCPConfigDataSource{}
+
+func NewDHCPConfigDataSource() datasource.DataSource {
+	return &DHCPConfigDataSource{}
+}
+
+type DHCPConfigDataSource struct {
+	client *PiholeClient
+}
+
+type DHCPConfigDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Active      types.Bool   `tfsdk:"active"`
+	Start       types.String `tfsdk:"start"`
+	End         types.String `tfsdk:"end"`
+	Router      types.String `tfsdk:"router"`
+	LeaseTime   types.String `tfsdk:"lease_time"`
+	IPv6        types.Bool   `tfsdk:"ipv6"`
+	RapidCommit types.Bool   `tfsdk:"rapid_commit"`
+}
+
+func (d *DHCPConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_config"
+}
+
+func (d *DHCPConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the configuration of Pi-hole's DHCP server, e.g. to keep static addresses out of the DHCP range",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the DHCP server is enabled",
+				Computed:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "First address of the DHCP range, empty if not configured",
+				Computed:            true,
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "Last address of the DHCP range, empty if not configured",
+				Computed:            true,
+			},
+			"router": schema.StringAttribute{
+				MarkdownDescription: "Gateway address handed out to DHCP clients, empty if not configured",
+				Computed:            true,
+			},
+			"lease_time": schema.StringAttribute{
+				MarkdownDescription: "Lease time, e.g. `24h`. Empty if Pi-hole uses its default lease time",
+				Computed:            true,
+			},
+			"ipv6": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCPv6 and router advertisements are enabled",
+				Computed:            true,
+			},
+			"rapid_commit": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCPv4 rapid commit is enabled",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DHCPConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DHCPConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DHCPConfigDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dhcpConfig, err := d.client.GetConfigSection("dhcp")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read DHCP configuration: "+err.Error())
+		return
+	}
+
+	// Settings missing in older Pi-hole versions are reported as disabled or empty
+	stringValue := func(key string) types.String {
+		value, _ := dhcpConfig[key].(string)
+		return types.StringValue(value)
+	}
+	boolValue := func(key string) types.Bool {
+		value, _ := dhcpConfig[key].(bool)
+		return types.BoolValue(value)
+	}
+
+	data.ID = types.StringValue("dhcp_config")
+	data.Active = boolValue("active")
+	data.Start = stringValue("start")
+	data.End = stringValue("end")
+	data.Router = stringValue("router")
+	data.LeaseTime = stringValue("leaseTime")
+	data.IPv6 = boolValue("ipv6")
+	data.RapidCommit = boolValue("rapidCommit")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestDHCPConfigDataSource_Metadata(t *testing.T) {
+	d := NewDHCPConfigDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_dhcp_config" {
+		t.Errorf("Expected TypeName to be 'pihole_dhcp_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestDHCPConfigDataSource_Read(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dhcp": {
+			"active":      true,
+			"start":       "192.168.1.100",
+			"end":         "192.168.1.200",
+			"router":      "192.168.1.1",
+			"netmask":     "",
+			"leaseTime":   "24h",
+			"ipv6":        false,
+			"rapidCommit": true,
+			"hosts":       []string{"00:11:22:33:44:55,192.168.1.50,printer"},
+		},
+	})
+	defer server.Close()
+
+	d := NewDHCPConfigDataSource()
+	d.(*DHCPConfigDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data DHCPConfigDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	if data.ID.ValueString() != "dhcp_config" {
+		t.Errorf("Expected id 'dhcp_config', got '%s'", data.ID.ValueString())
+	}
+	if !data.Active.ValueBool() || data.IPv6.ValueBool() || !data.RapidCommit.ValueBool() {
+		t.Errorf("Expected active/ipv6/rapid_commit true/false/true, got %s/%s/%s", data.Active, data.IPv6, data.RapidCommit)
+	}
+	if data.Start.ValueString() != "192.168.1.100" || data.End.ValueString() != "192.168.1.200" {
+		t.Errorf("Expected range 192.168.1.100-192.168.1.200, got %s-%s", data.Start.ValueString(), data.End.ValueString())
+	}
+	if data.Router.ValueString() != "192.168.1.1" || data.LeaseTime.ValueString() != "24h" {
+		t.Errorf("Expected router 192.168.1.1 and lease time 24h, got %s and %s", data.Router.ValueString(), data.LeaseTime.ValueString())
+	}
+}
+
+func TestDHCPConfigDataSource_ReadToleratesMissingSettings(t *testing.T) {
+	// An older Pi-hole without the rapidCommit and ipv6 settings
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dhcp": {
+			"active": false,
+			"start":  "",
+			"end":    "",
+		},
+	})
+	defer server.Close()
+
+	d := NewDHCPConfigDataSource()
+	d.(*DHCPConfigDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data DHCPConfigDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	if data.Active.ValueBool() || data.RapidCommit.IsNull() || data.RapidCommit.ValueBool() || data.Router.ValueString() != "" {
+		t.Errorf("Expected missing settings to be read as disabled or empty, got %+v", data)
+	}
+}
@@ -83,11 +83,12 @@ func (d *DNSRecordDataSource) Read(ctx context.Context, req datasource.ReadReque
 	domain := data.Domain.ValueString()
 
 	// Get all DNS records from Pi-hole
-	records, err := d.client.GetDNSRecords()
+	snapshot, err := d.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", "Unable to read DNS records: "+err.Error())
 		return
 	}
+	records := snapshot.DNSRecords
 
 	// Find the specific record
 	var foundRecord *DNSRecord
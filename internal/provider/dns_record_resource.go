@@ -2,20 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &DNSRecordResource{}
+var _ resource.ResourceWithModifyPlan = &DNSRecordResource{}
 
 func NewDNSRecordResource() resource.Resource {
 	return &DNSRecordResource{}
@@ -26,9 +31,12 @@ type DNSRecordResource struct {
 }
 
 type DNSRecordResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	Domain types.String `tfsdk:"domain"`
-	IP     types.String `tfsdk:"ip"`
+	ID             types.String `tfsdk:"id"`
+	Domain         types.String `tfsdk:"domain"`
+	IP             types.String `tfsdk:"ip"`
+	FQDN           types.String `tfsdk:"fqdn"`
+	UpdateStrategy types.String `tfsdk:"update_strategy"`
+	CreateIfAbsent types.Bool   `tfsdk:"create_if_absent"`
 }
 
 func (r *DNSRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,6 +78,33 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 					),
 				},
 			},
+			"update_strategy": schema.StringAttribute{
+				MarkdownDescription: "How IP changes are applied: `in_place` rewrites the record in a single request so the domain " +
+					"keeps resolving, `recreate` deletes the record and creates it again, which briefly leaves the domain " +
+					"unresolvable (default: `in_place`)",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("in_place"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("in_place", "recreate"),
+				},
+			},
+			"create_if_absent": schema.BoolAttribute{
+				MarkdownDescription: "Whether creating the resource may take over a record Pi-hole already has for the domain " +
+					"and point it to `ip`. When `false`, creating the resource fails if the domain already resolves to " +
+					"a different IP, so records created outside of Terraform aren't changed by accident (default: `true`)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"fqdn": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Fully-qualified domain name the record resolves as. Unqualified host names are " +
+					"expanded with Pi-hole's local domain (`dns.domain`), e.g. `nas` becomes `nas.lan`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -91,6 +126,10 @@ func (r *DNSRecordResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+func (r *DNSRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	enforceLocalSuffix(ctx, r.client, req, resp)
+}
+
 func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data DNSRecordResourceModel
 
@@ -100,7 +139,40 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	err := r.client.CreateDNSRecord(data.Domain.ValueString(), data.IP.ValueString())
+	// A domain can't be both an A record and a CNAME. Resources can't see each other's configuration
+	// at plan time, so the collision is caught against what is already stored in Pi-hole.
+	cnameRecords, err := r.client.GetCNAMERecords()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CNAME records, got error: %s", err))
+		return
+	}
+	for _, record := range cnameRecords {
+		if strings.EqualFold(record.Domain, data.Domain.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("domain"),
+				"Conflicting DNS Record",
+				fmt.Sprintf("'%s' already is a CNAME for %s. A domain can't be both an A record and a CNAME, "+
+					"remove the CNAME record or choose a different domain.", data.Domain.ValueString(), record.Target),
+			)
+			return
+		}
+	}
+
+	// CreateDNSRecord points an existing record of the domain to the new IP, which is only wanted if allowed
+	if data.CreateIfAbsent.ValueBool() {
+		err = r.client.CreateDNSRecord(data.Domain.ValueString(), data.IP.ValueString())
+	} else {
+		err = r.client.CreateDNSRecordIfAbsent(data.Domain.ValueString(), data.IP.ValueString())
+	}
+	if errors.Is(err, ErrDNSRecordExists) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Existing DNS Record",
+			fmt.Sprintf("%s, and create_if_absent is false. Import the record to manage it, remove it from Pi-hole, "+
+				"or set create_if_absent to true to point it to %s.", err, data.IP.ValueString()),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create DNS record, got error: %s", err))
 		return
@@ -108,6 +180,11 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 
 	data.ID = data.Domain
 
+	if err := r.setFQDN(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local domain, got error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -120,14 +197,14 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	records, err := r.client.GetDNSRecords()
+	snapshot, err := r.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records, got error: %s", err))
 		return
 	}
 
 	found := false
-	for _, record := range records {
+	for _, record := range snapshot.DNSRecords {
 		if record.Domain == data.Domain.ValueString() {
 			data.IP = types.StringValue(record.IP)
 			found = true
@@ -140,6 +217,19 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Imported records, and state written before these attributes existed, have no value for them yet
+	if data.UpdateStrategy.IsNull() {
+		data.UpdateStrategy = types.StringValue("in_place")
+	}
+	if data.CreateIfAbsent.IsNull() {
+		data.CreateIfAbsent = types.BoolValue(true)
+	}
+
+	if err := r.setFQDN(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local domain, got error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -152,12 +242,41 @@ func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	err := r.client.UpdateDNSRecord(data.Domain.ValueString(), data.IP.ValueString())
+	var state DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only update_strategy or create_if_absent changed, the record in Pi-hole is already what the plan asks for
+	if data.IP.ValueString() == state.IP.ValueString() {
+		if err := r.setFQDN(&data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local domain, got error: %s", err))
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var err error
+	if data.UpdateStrategy.ValueString() == "recreate" {
+		err = r.client.UpdateDNSRecord(data.Domain.ValueString(), data.IP.ValueString())
+	} else {
+		err = r.client.SwapDNSRecordIP(ctx, data.Domain.ValueString(), state.IP.ValueString(), data.IP.ValueString())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update DNS record, got error: %s", err))
 		return
 	}
 
+	if err := r.setFQDN(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local domain, got error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -184,3 +303,47 @@ func (r *DNSRecordResource) ImportState(ctx context.Context, req resource.Import
 	// Set the ID to match the domain for consistency
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
 }
+
+// setFQDN computes the fully-qualified name from the record domain and Pi-hole's cached local domain
+func (r *DNSRecordResource) setFQDN(data *DNSRecordResourceModel) error {
+	localDomain, err := r.client.GetLocalDomain()
+	if err != nil {
+		return err
+	}
+
+	data.FQDN = types.StringValue(qualifyDomain(data.Domain.ValueString(), localDomain))
+	return nil
+}
+
+// enforceLocalSuffix rejects a new record whose domain is outside the provider's enforce_local_tld suffixes.
+// Records already in the state are left alone, so enabling the option doesn't block plans of existing ones.
+func enforceLocalSuffix(ctx context.Context, client *PiholeClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy, or before the provider is configured
+	if client == nil || len(client.Config.LocalSuffixes) == 0 || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var domain types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if resp.Diagnostics.HasError() || domain.IsNull() || domain.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var priorDomain types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domain"), &priorDomain)...)
+		if resp.Diagnostics.HasError() || priorDomain.Equal(domain) {
+			return
+		}
+	}
+
+	if !hasLocalSuffix(domain.ValueString(), client.Config.LocalSuffixes) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Domain Outside Local Suffixes",
+			fmt.Sprintf("'%s' doesn't end in any of the suffixes allowed by the provider's enforce_local_tld (%s). "+
+				"Check the domain for typos, or add its suffix to enforce_local_tld.",
+				domain.ValueString(), strings.Join(client.Config.LocalSuffixes, ", ")),
+		)
+	}
+}
@@ -2,13 +2,23 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -112,6 +122,27 @@ func TestAccPiholeDNSRecord_multipleRecords(t *testing.T) {
 	})
 }
 
+func TestAccPiholeDNSRecord_renameCreateBeforeDestroy(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPiholeDNSRecordConfigCreateBeforeDestroy("rename-old.example.com"),
+				Check:  resource.TestCheckResourceAttr("pihole_dns_record.test", "id", "rename-old.example.com"),
+			},
+			{
+				Config: testAccPiholeDNSRecordConfigCreateBeforeDestroy("rename-new.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.test", "id", "rename-new.example.com"),
+					resource.TestCheckResourceAttr("pihole_dns_record.test", "domain", "rename-new.example.com"),
+					testAccCheckPiholeDNSRecordAbsent("rename-old.example.com"),
+				),
+			},
+		},
+	})
+}
+
 func testAccPiholeProviderBlock() string {
 	url := os.Getenv("PIHOLE_URL")
 	if url == "" {
@@ -168,6 +199,48 @@ resource "pihole_dns_record" "test3" {
 `, testAccPiholeProviderBlock())
 }
 
+func testAccPiholeDNSRecordConfigCreateBeforeDestroy(domain string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "pihole_dns_record" "test" {
+  domain = %[2]q
+  ip     = "192.168.1.40"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, testAccPiholeProviderBlock(), domain)
+}
+
+// testAccCheckPiholeDNSRecordAbsent verifies Pi-hole has no record for the domain anymore
+func testAccCheckPiholeDNSRecordAbsent(domain string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := getOrCreateClient(context.Background(), os.Getenv("PIHOLE_URL"), os.Getenv("PIHOLE_PASSWORD"), ClientConfig{
+			MaxConnections: 1,
+			RequestDelayMs: 300,
+			RetryAttempts:  3,
+			RetryBackoffMs: 500,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create client: %v", err)
+		}
+
+		records, err := client.GetDNSRecords()
+		if err != nil {
+			return fmt.Errorf("failed to list DNS records: %v", err)
+		}
+		for _, record := range records {
+			if record.Domain == domain {
+				return fmt.Errorf("expected the record for %s to be deleted, it still points to %s", domain, record.IP)
+			}
+		}
+
+		return nil
+	}
+}
+
 // testAccCheckPiholeDNSRecordExists verifies the DNS record exists in the state
 func testAccCheckPiholeDNSRecordExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -215,7 +288,7 @@ func testAccCheckPiholeDNSRecordDestroy(resourceName string) resource.TestCheckF
 			return fmt.Errorf("PIHOLE_URL and PIHOLE_PASSWORD must be set for disappears test")
 		}
 
-		client, err := getOrCreateClient(url, password, config)
+		client, err := getOrCreateClient(context.Background(), url, password, config)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %v", err)
 		}
@@ -269,6 +342,13 @@ func TestDNSRecordResource_Schema(t *testing.T) {
 	} else if !idAttr.IsComputed() {
 		t.Error("'id' attribute should be computed")
 	}
+
+	strategyAttr, exists := schemaResp.Schema.Attributes["update_strategy"]
+	if !exists {
+		t.Error("Schema should have 'update_strategy' attribute")
+	} else if !strategyAttr.IsOptional() || !strategyAttr.IsComputed() {
+		t.Error("'update_strategy' attribute should be optional and computed")
+	}
 }
 
 func TestDNSRecordResource_Metadata(t *testing.T) {
@@ -340,3 +420,750 @@ func BenchmarkDNSRecordRead(b *testing.B) {
 		}
 	}
 }
+
+func TestQualifyDomain(t *testing.T) {
+	testCases := []struct {
+		domain      string
+		localDomain string
+		expected    string
+	}{
+		{"nas", "lan", "nas.lan"},
+		{"nas.homelab.local", "lan", "nas.homelab.local"},
+		{"nas", "", "nas"},
+		{"nas.lan", "lan", "nas.lan"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.domain+"/"+tc.localDomain, func(t *testing.T) {
+			result := qualifyDomain(tc.domain, tc.localDomain)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestDNSRecordResource_ReadComputesFQDN(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {
+			"domain": "lan",
+			"hosts":  []interface{}{"192.168.1.20 nas", "192.168.1.30 printer.office.example.com"},
+		},
+	})
+	defer server.Close()
+
+	r := NewDNSRecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	testCases := []struct {
+		domain string
+		ip     string
+		fqdn   string
+	}{
+		{"nas", "192.168.1.20", "nas.lan"},
+		{"printer.office.example.com", "192.168.1.30", "printer.office.example.com"},
+	}
+
+	for _, tc := range testCases {
+		resp := testResourceRead(t, r, map[string]tftypes.Value{
+			"id":     tftypes.NewValue(tftypes.String, tc.domain),
+			"domain": tftypes.NewValue(tftypes.String, tc.domain),
+			"ip":     tftypes.NewValue(tftypes.String, tc.ip),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+		}
+
+		var fqdn types.String
+		resp.State.GetAttribute(context.Background(), path.Root("fqdn"), &fqdn)
+		if fqdn.ValueString() != tc.fqdn {
+			t.Errorf("Expected fqdn '%s' for domain '%s', got '%s'", tc.fqdn, tc.domain, fqdn.ValueString())
+		}
+	}
+
+	// The local domain is looked up once and cached on the client
+	if count := server.requestCount("GET /api/config/dns"); count != 1 {
+		t.Errorf("Expected the local domain to be fetched once, got %d requests", count)
+	}
+}
+
+func TestPiholeClient_GetLocalDomain_NestedFormat(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"domain": map[string]interface{}{"name": "home.arpa", "local": true}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	localDomain, err := client.GetLocalDomain()
+	if err != nil {
+		t.Fatalf("Failed to get local domain: %v", err)
+	}
+
+	if localDomain != "home.arpa" {
+		t.Errorf("Expected local domain 'home.arpa', got '%s'", localDomain)
+	}
+}
+
+func TestDNSRecordResource_CRUDRoundTrip(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{}, "cnameRecords": []string{}, "domain": "lan"},
+	})
+	defer server.Close()
+
+	r := NewDNSRecordResource()
+	client := newTestClient(t, server.URL)
+	testConfigureResource(t, r, client)
+
+	createResp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	}
+
+	readResp := testResourceRead(t, r, state)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("Expected the created record to be found on read")
+	}
+
+	// A change made outside of Terraform shows up as drift in the next refresh, which doesn't share the
+	// snapshot of the records with this one
+	server.setValue("dns.hosts", []string{"192.168.1.99 nas.example.com"})
+	client.invalidateSnapshot()
+
+	readResp = testResourceRead(t, r, state)
+	var ip types.String
+	readResp.State.GetAttribute(context.Background(), path.Root("ip"), &ip)
+	if ip.ValueString() != "192.168.1.99" {
+		t.Errorf("Expected read to reflect the out-of-band IP change, got '%s'", ip.ValueString())
+	}
+
+	deleteResp := testResourceDelete(t, r, state)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", deleteResp.Diagnostics.Errors())
+	}
+
+	readResp = testResourceRead(t, r, state)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Expected the resource to be removed from state after delete")
+	}
+}
+
+func TestDNSRecordResource_UpdateStrategies(t *testing.T) {
+	testCases := []struct {
+		strategy       string
+		expectedWrites []string
+	}{
+		{
+			strategy:       "in_place",
+			expectedWrites: []string{"PATCH /api/config"},
+		},
+		{
+			strategy: "recreate",
+			expectedWrites: []string{
+				"DELETE /api/config/dns/hosts/192.168.1.20 nas.example.com",
+				"PUT /api/config/dns/hosts/192.168.1.30 nas.example.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.strategy, func(t *testing.T) {
+			server := newFakePihole(map[string]map[string]interface{}{
+				"dns": {
+					"hosts": []string{
+						"192.168.1.10 router.example.com",
+						"192.168.1.20 nas.example.com",
+					},
+					"domain": "lan",
+				},
+			})
+			defer server.Close()
+
+			r := NewDNSRecordResource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			prior := map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "nas.example.com"),
+				"domain":          tftypes.NewValue(tftypes.String, "nas.example.com"),
+				"ip":              tftypes.NewValue(tftypes.String, "192.168.1.20"),
+				"update_strategy": tftypes.NewValue(tftypes.String, tc.strategy),
+			}
+			resp := testResourceUpdate(t, r, prior, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "nas.example.com"),
+				"domain":          tftypes.NewValue(tftypes.String, "nas.example.com"),
+				"ip":              tftypes.NewValue(tftypes.String, "192.168.1.30"),
+				"update_strategy": tftypes.NewValue(tftypes.String, tc.strategy),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			writes := server.writeRequests()
+			if fmt.Sprint(writes) != fmt.Sprint(tc.expectedWrites) {
+				t.Errorf("Expected write requests %v, got %v", tc.expectedWrites, writes)
+			}
+
+			expectedHosts := []string{"192.168.1.10 router.example.com", "192.168.1.30 nas.example.com"}
+			if hosts := server.hosts(); fmt.Sprint(hosts) != fmt.Sprint(expectedHosts) {
+				t.Errorf("Expected the record to point to the new IP and other records to be kept, got %v", hosts)
+			}
+		})
+	}
+}
+
+func TestDNSRecordResource_UpdateWithUnchangedIPSkipsWrites(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {
+			"hosts":  []string{"192.168.1.20 nas.example.com"},
+			"domain": "lan",
+		},
+	})
+	defer server.Close()
+
+	r := NewDNSRecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Only update_strategy changes, domain and ip stay the same
+	resp := testResourceUpdate(t, r, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"domain":          tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"ip":              tftypes.NewValue(tftypes.String, "192.168.1.20"),
+		"update_strategy": tftypes.NewValue(tftypes.String, "in_place"),
+	}, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"domain":          tftypes.NewValue(tftypes.String, "nas.example.com"),
+		"ip":              tftypes.NewValue(tftypes.String, "192.168.1.20"),
+		"update_strategy": tftypes.NewValue(tftypes.String, "recreate"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if writes := server.writeRequests(); len(writes) != 0 {
+		t.Errorf("Expected no delete or create for an unchanged record, got %v", writes)
+	}
+
+	var strategy, fqdn types.String
+	resp.State.GetAttribute(context.Background(), path.Root("update_strategy"), &strategy)
+	resp.State.GetAttribute(context.Background(), path.Root("fqdn"), &fqdn)
+	if strategy.ValueString() != "recreate" || fqdn.ValueString() != "nas.example.com" {
+		t.Errorf("Expected the new update_strategy and the fqdn in state, got %s/%s", strategy, fqdn)
+	}
+}
+
+func TestDNSRecordResource_UpdateInPlaceKeepsOrder(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {
+			"hosts": []string{
+				"192.168.1.10 router.example.com",
+				"192.168.1.20 nas.example.com",
+				"192.168.1.40 printer.example.com",
+			},
+		},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.SwapDNSRecordIP(context.Background(), "nas.example.com", "192.168.1.20", "192.168.1.30"); err != nil {
+		t.Fatalf("SwapDNSRecordIP failed: %v", err)
+	}
+
+	expected := []string{
+		"192.168.1.10 router.example.com",
+		"192.168.1.30 nas.example.com",
+		"192.168.1.40 printer.example.com",
+	}
+	if hosts := server.hosts(); fmt.Sprint(hosts) != fmt.Sprint(expected) {
+		t.Errorf("Expected only the matching line to change, got %v", hosts)
+	}
+}
+
+func TestPiholeClient_SwapDNSRecordIPAlwaysResolves(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{"192.168.1.10 router.example.com", "192.168.1.20 nas.example.com"}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.SwapDNSRecordIP(context.Background(), "nas.example.com", "192.168.1.20", "192.168.1.30"); err != nil {
+		t.Fatalf("SwapDNSRecordIP failed: %v", err)
+	}
+
+	// Every state the table went through must resolve the domain to exactly one IP
+	snapshots := server.hostsSnapshots()
+	if len(snapshots) == 0 {
+		t.Fatal("Expected the swap to change the table")
+	}
+	for i, hosts := range snapshots {
+		var ips []string
+		for _, line := range hosts {
+			if parts := strings.SplitN(line, " ", 2); len(parts) == 2 && parts[1] == "nas.example.com" {
+				ips = append(ips, parts[0])
+			}
+		}
+		if len(ips) != 1 {
+			t.Errorf("Expected nas.example.com to resolve to exactly one IP after write %d, got %v", i+1, ips)
+		}
+	}
+
+	if hosts := server.hosts(); hosts[1] != "192.168.1.30 nas.example.com" {
+		t.Errorf("Expected nas.example.com to point to the new IP, got %v", hosts)
+	}
+}
+
+func TestDNSRecordResource_RenameCreateBeforeDestroy(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{"192.168.1.20 old.example.com"}},
+	})
+	defer server.Close()
+
+	r := NewDNSRecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// With create_before_destroy, Terraform creates the renamed record before it destroys the old one
+	createResp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "new.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+
+	var id types.String
+	createResp.State.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "new.example.com" {
+		t.Errorf("Expected the new record to have id new.example.com, got %s", id)
+	}
+
+	deleteResp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "old.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "old.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", deleteResp.Diagnostics.Errors())
+	}
+
+	// Every state the table went through must resolve at least one of the names
+	for i, hosts := range server.hostsSnapshots() {
+		if !slices.Contains(hosts, "192.168.1.20 old.example.com") && !slices.Contains(hosts, "192.168.1.20 new.example.com") {
+			t.Errorf("Expected one of the names to resolve after write %d, got %v", i+1, hosts)
+		}
+	}
+
+	// Deleting the old name must leave the new record with the same IP alone
+	if hosts := server.hosts(); !reflect.DeepEqual(hosts, []string{"192.168.1.20 new.example.com"}) {
+		t.Errorf("Expected only the renamed record to remain, got %v", hosts)
+	}
+}
+
+func TestPiholeClient_SwapDNSRecordIPRejectsUnexpectedIP(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{"192.168.1.25 nas.example.com"}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.SwapDNSRecordIP(context.Background(), "nas.example.com", "192.168.1.20", "192.168.1.30")
+	if err == nil || !strings.Contains(err.Error(), "192.168.1.25") {
+		t.Fatalf("Expected an error naming the unexpected IP, got: %v", err)
+	}
+
+	if writes := server.writeRequests(); len(writes) != 0 {
+		t.Errorf("Expected no writes when the record changed outside of Terraform, got %v", writes)
+	}
+}
+
+func TestPiholeClient_DetectsCustomDNSEndpoint(t *testing.T) {
+	// A Pi-hole release that stores local DNS records as dns.customDNS instead of dns.hosts
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"customDNS": []string{"192.168.1.10 router.example.com"}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	records, err := client.GetDNSRecords()
+	if err != nil {
+		t.Fatalf("GetDNSRecords failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Domain != "router.example.com" {
+		t.Errorf("Expected the record stored under dns.customDNS, got %v", records)
+	}
+
+	if err := client.CreateDNSRecord("nas.example.com", "192.168.1.20"); err != nil {
+		t.Fatalf("CreateDNSRecord failed: %v", err)
+	}
+	if err := client.SwapDNSRecordIP(context.Background(), "nas.example.com", "192.168.1.20", "192.168.1.30"); err != nil {
+		t.Fatalf("SwapDNSRecordIP failed: %v", err)
+	}
+	if err := client.DeleteDNSRecord("router.example.com"); err != nil {
+		t.Fatalf("DeleteDNSRecord failed: %v", err)
+	}
+
+	if stored := server.stringList("dns", "customDNS"); fmt.Sprint(stored) != "[192.168.1.30 nas.example.com]" {
+		t.Errorf("Expected all changes to go to dns.customDNS, got %v", stored)
+	}
+
+	// dns.hosts is only probed once, the detected endpoint is cached on the client
+	if count := server.requestCount("GET /api/config/dns/hosts"); count != 1 {
+		t.Errorf("Expected a single probe of dns.hosts, got %d", count)
+	}
+	for _, request := range server.writeRequests() {
+		if strings.Contains(request, "/api/config/dns/hosts") {
+			t.Errorf("Expected no writes to dns.hosts, got %s", request)
+		}
+	}
+}
+
+func TestPiholeClient_PrefersHostsEndpoint(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	if _, err := client.GetDNSRecords(); err != nil {
+		t.Fatalf("GetDNSRecords failed: %v", err)
+	}
+	if err := client.CreateDNSRecord("nas.example.com", "192.168.1.20"); err != nil {
+		t.Fatalf("CreateDNSRecord failed: %v", err)
+	}
+
+	if count := server.requestCount("GET /api/config/dns/customDNS"); count != 0 {
+		t.Errorf("Expected dns.customDNS not to be probed when dns.hosts exists, got %d requests", count)
+	}
+	if count := server.requestCount("PUT /api/config/dns/hosts/192.168.1.20 nas.example.com"); count != 1 {
+		t.Errorf("Expected the record to be created under dns.hosts, got %v", server.writeRequests())
+	}
+}
+
+func TestPiholeClient_DNSRecordReadsRunInParallel(t *testing.T) {
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	// Each read waits up to a second for a second one to arrive, which only happens if they aren't serialized
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config/dns/hosts" {
+			mu.Lock()
+			inFlight++
+			peak = max(peak, inFlight)
+			mu.Unlock()
+
+			for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+				mu.Lock()
+				reached := peak >= 2
+				mu.Unlock()
+				if reached {
+					break
+				}
+			}
+
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewPiholeClient(server.URL, "test-password", ClientConfig{MaxConnections: 2})
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	// The first read detects the endpoint, later ones only use the cached key
+	if _, err := client.GetDNSRecords(); err != nil {
+		t.Fatalf("GetDNSRecords failed: %v", err)
+	}
+	mu.Lock()
+	peak = 0
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetDNSRecords(); err != nil {
+				t.Errorf("GetDNSRecords failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("Expected both reads to be in flight at once with max_connections 2, got a peak of %d", peak)
+	}
+}
+
+func TestHasLocalSuffix(t *testing.T) {
+	suffixes := []string{"internal", ".home.arpa"}
+
+	testCases := []struct {
+		domain string
+		local  bool
+	}{
+		{"nas.internal", true},
+		{"NAS.Internal", true},
+		{"internal", true},
+		{"printer.lab.home.arpa", true},
+		{"home.arpa", true},
+		{"nas", true},
+		{"nas.internal.", true},
+		{"foo.com", false},
+		{"notinternal", true},
+		{"nas.notinternal", false},
+		{"arpa.example.com", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.domain, func(t *testing.T) {
+			if got := hasLocalSuffix(tc.domain, suffixes); got != tc.local {
+				t.Errorf("hasLocalSuffix(%q) = %v, want %v", tc.domain, got, tc.local)
+			}
+		})
+	}
+}
+
+func TestDNSRecordResource_EnforceLocalTLD(t *testing.T) {
+	client := &PiholeClient{Config: ClientConfig{LocalSuffixes: []string{"internal"}}}
+
+	r := NewDNSRecordResource()
+	testConfigureResource(t, r, client)
+
+	allowed := testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "nas.internal"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if allowed.Diagnostics.HasError() {
+		t.Errorf("Expected nas.internal to be allowed, got %v", allowed.Diagnostics.Errors())
+	}
+
+	rejected := testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "foo.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	})
+	if !rejected.Diagnostics.HasError() {
+		t.Fatal("Expected foo.com to be rejected")
+	}
+	if summary := rejected.Diagnostics.Errors()[0].Summary(); summary != "Domain Outside Local Suffixes" {
+		t.Errorf("Expected 'Domain Outside Local Suffixes', got '%s'", summary)
+	}
+
+	// Records created before the option was set keep planning
+	existing := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "foo.com"),
+		"domain": tftypes.NewValue(tftypes.String, "foo.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.20"),
+	}
+	if resp := testResourceModifyPlan(t, r, existing, existing); resp.Diagnostics.HasError() {
+		t.Errorf("Expected an existing record to be left alone, got %v", resp.Diagnostics.Errors())
+	}
+}
+
+func TestCNAMERecordResource_EnforceLocalTLD(t *testing.T) {
+	client := &PiholeClient{Config: ClientConfig{LocalSuffixes: []string{"internal"}}}
+
+	r := NewCNAMERecordResource()
+	testConfigureResource(t, r, client)
+
+	// Only the alias must be local, the target may point anywhere
+	allowed := testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "www.internal"),
+		"target": tftypes.NewValue(tftypes.String, "example.com"),
+	})
+	if allowed.Diagnostics.HasError() {
+		t.Errorf("Expected www.internal to be allowed, got %v", allowed.Diagnostics.Errors())
+	}
+
+	rejected := testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "www.foo.com"),
+		"target": tftypes.NewValue(tftypes.String, "nas.internal"),
+	})
+	if !rejected.Diagnostics.HasError() {
+		t.Error("Expected www.foo.com to be rejected")
+	}
+}
+
+// newVanishingRecordServer wraps the fake Pi-hole and answers every DELETE with not found, as Pi-hole does
+// when a record is removed out-of-band between listing the records and deleting one of them
+func newVanishingRecordServer(t *testing.T, fake *fakePihole) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/config/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"key":"not_found","message":"Item not found"}}`))
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDNSRecordResource_DeleteAbsentRecord(t *testing.T) {
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "test.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "test.example.com"),
+		"ip":     tftypes.NewValue(tftypes.String, "192.168.1.100"),
+	}
+
+	testCases := []struct {
+		name   string
+		server func(t *testing.T) string
+	}{
+		{"not listed", func(t *testing.T) string {
+			fake := newFakePihole(map[string]map[string]interface{}{"dns": {"hosts": []interface{}{}}})
+			t.Cleanup(fake.Close)
+			return fake.URL
+		}},
+		{"removed after listing", func(t *testing.T) string {
+			fake := createMockPiholeServer()
+			t.Cleanup(fake.Close)
+			return newVanishingRecordServer(t, fake).URL
+		}},
+		{"no records endpoint", func(t *testing.T) string {
+			fake := newFakePihole(map[string]map[string]interface{}{"dns": {"domain": "lan"}})
+			t.Cleanup(fake.Close)
+			return fake.URL
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewDNSRecordResource()
+			testConfigureResource(t, r, newTestClient(t, tc.server(t)))
+
+			resp := testResourceDelete(t, r, state)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("Expected deleting an absent record to succeed, got %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestDNSRecordResource_CreateIfAbsent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ip             string
+		createIfAbsent bool
+		expectErr      bool
+		expectedHosts  []string
+	}{
+		{"takes over by default", "10.0.0.1", true, false, []string{"192.168.1.101 server.example.com", "10.0.0.1 test.example.com"}},
+		{"rejects a different IP", "10.0.0.1", false, true, []string{"192.168.1.100 test.example.com", "192.168.1.101 server.example.com"}},
+		{"adopts the same IP", "192.168.1.100", false, false, []string{"192.168.1.100 test.example.com", "192.168.1.101 server.example.com"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := createMockPiholeServer()
+			defer server.Close()
+
+			r := NewDNSRecordResource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			// test.example.com already points to 192.168.1.100 in the fake
+			resp := testResourceCreate(t, r, map[string]tftypes.Value{
+				"domain":           tftypes.NewValue(tftypes.String, "test.example.com"),
+				"ip":               tftypes.NewValue(tftypes.String, tc.ip),
+				"update_strategy":  tftypes.NewValue(tftypes.String, "in_place"),
+				"create_if_absent": tftypes.NewValue(tftypes.Bool, tc.createIfAbsent),
+			})
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, resp.Diagnostics.Errors())
+			}
+			if tc.expectErr {
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Existing DNS Record" {
+					t.Errorf("Expected 'Existing DNS Record', got '%s'", summary)
+				}
+			}
+
+			if hosts := server.stringList("dns", "hosts"); !reflect.DeepEqual(hosts, tc.expectedHosts) {
+				t.Errorf("Expected dns.hosts %v, got %v", tc.expectedHosts, hosts)
+			}
+		})
+	}
+}
+
+func TestPiholeClient_CreateDNSRecordIfAbsentInParallel(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{"dns": {"hosts": []string{}}})
+	defer server.Close()
+
+	client, err := NewPiholeClient(server.URL, "test-password", ClientConfig{MaxConnections: 4})
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	// Both see no record of the domain before writing, unless the check and the write are one step
+	ips := []string{"10.0.0.1", "10.0.0.2"}
+	errs := make([]error, len(ips))
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = client.CreateDNSRecordIfAbsent("race.example.com", ip)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrDNSRecordExists):
+			t.Errorf("Expected ErrDNSRecordExists, got %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("Expected exactly one create to succeed, got %d", created)
+	}
+	if hosts := server.stringList("dns", "hosts"); len(hosts) != 1 {
+		t.Errorf("Expected a single record of the domain, got %v", hosts)
+	}
+}
+
+func TestDNSRecordResource_ReadImportedStateSetsDefaults(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	r := NewDNSRecordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Import only sets the id and domain, so the optional attributes are null until the first read
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "test.example.com"),
+		"domain": tftypes.NewValue(tftypes.String, "test.example.com"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var strategy types.String
+	var createIfAbsent types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("update_strategy"), &strategy)
+	resp.State.GetAttribute(context.Background(), path.Root("create_if_absent"), &createIfAbsent)
+	if strategy.ValueString() != "in_place" || !createIfAbsent.ValueBool() {
+		t.Errorf("Expected the schema defaults in_place/true after import, got %s/%s", strategy, createIfAbsent)
+	}
+}
@@ -19,8 +19,9 @@ type DNSRecordsDataSource struct {
 }
 
 type DNSRecordsDataSourceModel struct {
-	ID      types.String               `tfsdk:"id"`
-	Records []DNSRecordDataSourceModel `tfsdk:"records"`
+	ID          types.String               `tfsdk:"id"`
+	Records     []DNSRecordDataSourceModel `tfsdk:"records"`
+	RecordCount types.Int64                `tfsdk:"record_count"`
 }
 
 type DNSRecordDataSourceModel struct {
@@ -41,6 +42,10 @@ func (d *DNSRecordsDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "Data source identifier",
 				Computed:            true,
 			},
+			"record_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of DNS A records",
+				Computed:            true,
+			},
 			"records": schema.ListNestedAttribute{
 				MarkdownDescription: "List of DNS A records",
 				Computed:            true,
@@ -88,11 +93,12 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Get DNS records from Pi-hole
-	records, err := d.client.GetDNSRecords()
+	snapshot, err := d.client.Snapshot(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", "Unable to read DNS records: "+err.Error())
 		return
 	}
+	records := snapshot.DNSRecords
 
 	// Convert to data source model
 	recordModels := make([]DNSRecordDataSourceModel, 0, len(records))
@@ -104,6 +110,7 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	data.ID = types.StringValue("dns_records")
+	data.RecordCount = types.Int64Value(int64(len(records)))
 	data.Records = recordModels
 
 	// Save data into Terraform state
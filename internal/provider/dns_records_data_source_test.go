@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -114,6 +115,25 @@ func TestPiholeDNSRecordsDataSource_Schema(t *testing.T) {
 	}
 }
 
+func TestPiholeDNSRecordsDataSource_RecordCount(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	d := NewDNSRecordsDataSource()
+	d.(*DNSRecordsDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data DNSRecordsDataSourceModel
+	resp.State.Get(context.Background(), &data)
+	if data.RecordCount.ValueInt64() != int64(len(data.Records)) || len(data.Records) != 2 {
+		t.Errorf("Expected record_count to equal the 2 records returned, got %s for %d records", data.RecordCount, len(data.Records))
+	}
+}
+
 // Test configuration functions
 func testAccPiholeDNSRecordsDataSourceConfig_basic() string {
 	return fmt.Sprintf(`
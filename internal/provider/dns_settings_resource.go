@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &DNSSettingsResource{}
+
+// Pi-hole's defaults for the DNS settings, restored when the resource is deleted
+const (
+	defaultDNSCacheSize     = 10000
+	defaultDNSReplyWhenBusy = "ALLOW"
+	defaultDNSPort          = 53
+	defaultIgnoreLocalhost  = false
+)
+
+// dnsReplyWhenBusyModes are the answers FTL can give while the gravity database is busy, e.g. during an update
+var dnsReplyWhenBusyModes = []string{"ALLOW", "BLOCK", "REFUSE", "DROP"}
+
+func NewDNSSettingsResource() resource.Resource {
+	return &DNSSettingsResource{}
+}
+
+type DNSSettingsResource struct {
+	client *PiholeClient
+}
+
+type DNSSettingsResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	CacheSize       types.Int64  `tfsdk:"cache_size"`
+	ReplyWhenBusy   types.String `tfsdk:"reply_when_busy"`
+	DNSPort         types.Int64  `tfsdk:"dns_port"`
+	IgnoreLocalhost types.Bool   `tfsdk:"ignore_localhost"`
+}
+
+func (r *DNSSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_settings"
+}
+
+func (r *DNSSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages tuning settings of FTL's DNS resolver in the `dns` configuration section. " +
+			"Settings that are not set are left as they are in Pi-hole. " +
+			"Deleting this resource restores Pi-hole's defaults.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "DNS settings identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of DNS answers FTL keeps in its cache, `0` disables caching (`dns.cache.size`, Pi-hole default: 10000)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"reply_when_busy": schema.StringAttribute{
+				MarkdownDescription: "How FTL answers queries while the gravity database is busy, e.g. during a gravity update " +
+					"(`dns.replyWhenBusy`, Pi-hole default: `ALLOW`). One of `ALLOW` (answer without blocking), " +
+					"`BLOCK` (block the query), `REFUSE` (answer with REFUSED) or `DROP` (don't answer).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsReplyWhenBusyModes...),
+				},
+			},
+			"dns_port": schema.Int64Attribute{
+				MarkdownDescription: "Port FTL answers DNS queries on (`dns.port`, Pi-hole default: 53). " +
+					"**Warning**: clients and the DHCP server keep sending queries to the old port, so changing it " +
+					"stops name resolution for every client that isn't reconfigured as well.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"ignore_localhost": schema.BoolAttribute{
+				MarkdownDescription: "Whether FTL leaves queries from the Pi-hole machine itself out of the query log and " +
+					"the statistics (`dns.ignoreLocalhost`, Pi-hole default: `false`)",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DNSSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DNSSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to warn about when destroying, or when the port is unchanged
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DNSSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.DNSPort.IsNull() || plan.DNSPort.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state DNSSettingsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.DNSPort.Equal(plan.DNSPort) {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("dns_port"),
+		"DNS Port Change",
+		fmt.Sprintf("FTL will answer DNS queries on port %d after this apply. Clients that still query the old port "+
+			"get no answers until they are reconfigured.", plan.DNSPort.ValueInt64()),
+	)
+}
+
+func (r *DNSSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", dnsSettingsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set DNS settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", dnsSettingsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update DNS settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	defaults := DNSSettingsResourceModel{
+		CacheSize:       types.Int64Value(defaultDNSCacheSize),
+		ReplyWhenBusy:   types.StringValue(defaultDNSReplyWhenBusy),
+		DNSPort:         types.Int64Value(defaultDNSPort),
+		IgnoreLocalhost: types.BoolValue(defaultIgnoreLocalhost),
+	}
+
+	if err := r.client.SetConfigSection("dns", dnsSettingsValues(defaults)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset DNS settings, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the DNS settings currently active in Pi-hole
+func (r *DNSSettingsResource) readInto(data *DNSSettingsResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	cache, ok := dnsConfig["cache"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.cache: %v", dnsConfig["cache"])
+	}
+	cacheSize, ok := cache["size"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.cache.size: %v", cache["size"])
+	}
+	replyWhenBusy, ok := dnsConfig["replyWhenBusy"].(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.replyWhenBusy: %v", dnsConfig["replyWhenBusy"])
+	}
+	port, ok := dnsConfig["port"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.port: %v", dnsConfig["port"])
+	}
+	ignoreLocalhost, ok := dnsConfig["ignoreLocalhost"].(bool)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.ignoreLocalhost: %v", dnsConfig["ignoreLocalhost"])
+	}
+
+	data.ID = types.StringValue("dns_settings")
+	data.CacheSize = types.Int64Value(int64(cacheSize))
+	data.ReplyWhenBusy = types.StringValue(replyWhenBusy)
+	data.DNSPort = types.Int64Value(int64(port))
+	data.IgnoreLocalhost = types.BoolValue(ignoreLocalhost)
+
+	return nil
+}
+
+// dnsSettingsValues builds the dns section payload from the planned model, leaving unset settings out
+func dnsSettingsValues(data DNSSettingsResourceModel) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	if !data.CacheSize.IsNull() && !data.CacheSize.IsUnknown() {
+		values["cache"] = map[string]interface{}{"size": data.CacheSize.ValueInt64()}
+	}
+	if !data.ReplyWhenBusy.IsNull() && !data.ReplyWhenBusy.IsUnknown() {
+		values["replyWhenBusy"] = data.ReplyWhenBusy.ValueString()
+	}
+	if !data.DNSPort.IsNull() && !data.DNSPort.IsUnknown() {
+		values["port"] = data.DNSPort.ValueInt64()
+	}
+	if !data.IgnoreLocalhost.IsNull() && !data.IgnoreLocalhost.IsUnknown() {
+		values["ignoreLocalhost"] = data.IgnoreLocalhost.ValueBool()
+	}
+
+	return values
+}
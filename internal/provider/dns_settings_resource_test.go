@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dnsSettingsFakeSections is a dns section with the managed settings next to unrelated ones
+func dnsSettingsFakeSections(cacheSize int) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"port":            53,
+			"cache":           map[string]interface{}{"size": cacheSize, "optimizer": 3600},
+			"replyWhenBusy":   "ALLOW",
+			"ignoreLocalhost": false,
+		},
+	}
+}
+
+func TestDNSSettingsResource_Metadata(t *testing.T) {
+	r := NewDNSSettingsResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_dns_settings" {
+		t.Errorf("Expected TypeName to be 'pihole_dns_settings', got '%s'", resp.TypeName)
+	}
+}
+
+func TestDNSSettingsResource_SetCacheSize(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(10000))
+	defer server.Close()
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"cache_size": tftypes.NewValue(tftypes.Number, 50000),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	cache := dnsConfig["cache"].(map[string]interface{})
+	if cache["size"] != 50000.0 {
+		t.Errorf("Expected dns.cache.size 50000, got %v", cache["size"])
+	}
+	// Unrelated dns settings must be left untouched by the PATCH
+	if cache["optimizer"] != 3600.0 || dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns settings to be preserved, got %v", dnsConfig)
+	}
+
+	var cacheSize types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("cache_size"), &cacheSize)
+	if cacheSize.ValueInt64() != 50000 {
+		t.Errorf("Expected cache_size 50000 in state, got %s", cacheSize)
+	}
+}
+
+func TestDNSSettingsResource_SetReplyWhenBusy(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(10000))
+	defer server.Close()
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"cache_size":      tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"reply_when_busy": tftypes.NewValue(tftypes.String, "DROP"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	if dnsConfig["replyWhenBusy"] != "DROP" {
+		t.Errorf("Expected dns.replyWhenBusy DROP, got %v", dnsConfig["replyWhenBusy"])
+	}
+
+	var replyWhenBusy types.String
+	var cacheSize types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("reply_when_busy"), &replyWhenBusy)
+	resp.State.GetAttribute(context.Background(), path.Root("cache_size"), &cacheSize)
+	if replyWhenBusy.ValueString() != "DROP" || cacheSize.ValueInt64() != 10000 {
+		t.Errorf("Expected state DROP with the unset cache size read back as 10000, got %s/%s", replyWhenBusy, cacheSize)
+	}
+}
+
+func TestDNSSettingsResource_ReplyWhenBusyValidation(t *testing.T) {
+	r := NewDNSSettingsResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	attr := schemaResp.Schema.Attributes["reply_when_busy"].(schema.StringAttribute)
+
+	testCases := []struct {
+		value     string
+		expectErr bool
+	}{
+		{"ALLOW", false},
+		{"BLOCK", false},
+		{"REFUSE", false},
+		{"DROP", false},
+		{"allow", true},
+		{"NXDOMAIN", true},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("reply_when_busy"),
+				ConfigValue: types.StringValue(tc.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For reply_when_busy '%s': expected error %v, got %v", tc.value, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestDNSSettingsResource_Validation(t *testing.T) {
+	r := NewDNSSettingsResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	testCases := []struct {
+		attribute string
+		value     int64
+		expectErr bool
+	}{
+		{"cache_size", 10000, false},
+		{"cache_size", 0, false},
+		{"cache_size", -1, true},
+		{"dns_port", 53, false},
+		{"dns_port", 1, false},
+		{"dns_port", 65535, false},
+		{"dns_port", 0, true},
+		{"dns_port", 65536, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s=%d", tc.attribute, tc.value), func(t *testing.T) {
+			attr := schemaResp.Schema.Attributes[tc.attribute].(schema.Int64Attribute)
+			req := validator.Int64Request{
+				Path:        path.Root(tc.attribute),
+				ConfigValue: types.Int64Value(tc.value),
+			}
+			resp := &validator.Int64Response{}
+			for _, v := range attr.Validators {
+				v.ValidateInt64(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For %s=%d: expected error %v, got %v", tc.attribute, tc.value, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestDNSSettingsResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(2000))
+	defer server.Close()
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "dns_settings"),
+		"cache_size": tftypes.NewValue(tftypes.Number, 50000),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var cacheSize types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("cache_size"), &cacheSize)
+	if cacheSize.ValueInt64() != 2000 {
+		t.Errorf("Expected state to reflect Pi-hole's cache size 2000, got %s", cacheSize)
+	}
+}
+
+func TestDNSSettingsResource_DeleteRestoresDefaults(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(0))
+	defer server.Close()
+	server.setValue("dns.replyWhenBusy", "DROP")
+	server.setValue("dns.ignoreLocalhost", true)
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "dns_settings"),
+		"cache_size": tftypes.NewValue(tftypes.Number, 0),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	cache := dnsConfig["cache"].(map[string]interface{})
+	if cache["size"] != 10000.0 || dnsConfig["replyWhenBusy"] != "ALLOW" || dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected DNS settings to be reset to 10000/ALLOW/53, got %v/%v/%v", cache["size"], dnsConfig["replyWhenBusy"], dnsConfig["port"])
+	}
+	if dnsConfig["ignoreLocalhost"] != false {
+		t.Errorf("Expected dns.ignoreLocalhost to be reset to false, got %v", dnsConfig["ignoreLocalhost"])
+	}
+}
+
+func TestDNSSettingsResource_SetDNSPort(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(10000))
+	defer server.Close()
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"cache_size":      tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"reply_when_busy": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"dns_port":        tftypes.NewValue(tftypes.Number, 5353),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if port := server.section("dns")["port"]; port != 5353.0 {
+		t.Errorf("Expected dns.port 5353, got %v", port)
+	}
+
+	var port types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("dns_port"), &port)
+	if port.ValueInt64() != 5353 {
+		t.Errorf("Expected dns_port 5353 in state, got %s", port)
+	}
+}
+
+func TestDNSSettingsResource_ModifyPlanWarnsOnDNSPortChange(t *testing.T) {
+	r := NewDNSSettingsResource()
+
+	settings := func(port interface{}) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "dns_settings"),
+			"cache_size":      tftypes.NewValue(tftypes.Number, 10000),
+			"reply_when_busy": tftypes.NewValue(tftypes.String, "ALLOW"),
+			"dns_port":        tftypes.NewValue(tftypes.Number, port),
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		prior       map[string]tftypes.Value
+		planned     map[string]tftypes.Value
+		expectWarns bool
+	}{
+		{"changed port", settings(53), settings(5353), true},
+		{"unchanged port", settings(53), settings(53), false},
+		{"port set on create", nil, settings(5353), true},
+		{"port read on create", nil, settings(tftypes.UnknownValue), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testResourceModifyPlan(t, r, tc.prior, tc.planned)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan has errors: %v", resp.Diagnostics.Errors())
+			}
+			if warned := resp.Diagnostics.WarningsCount() > 0; warned != tc.expectWarns {
+				t.Errorf("Expected warning %v, got %v", tc.expectWarns, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func TestDNSSettingsResource_ToggleIgnoreLocalhost(t *testing.T) {
+	server := newFakePihole(dnsSettingsFakeSections(10000))
+	defer server.Close()
+
+	r := NewDNSSettingsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	settings := func(ignoreLocalhost bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":               tftypes.NewValue(tftypes.String, "dns_settings"),
+			"cache_size":       tftypes.NewValue(tftypes.Number, 10000),
+			"reply_when_busy":  tftypes.NewValue(tftypes.String, "ALLOW"),
+			"dns_port":         tftypes.NewValue(tftypes.Number, 53),
+			"ignore_localhost": tftypes.NewValue(tftypes.Bool, ignoreLocalhost),
+		}
+	}
+
+	createResp := testResourceCreate(t, r, settings(true))
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+	if server.section("dns")["ignoreLocalhost"] != true {
+		t.Errorf("Expected dns.ignoreLocalhost to be enabled, got %v", server.section("dns")["ignoreLocalhost"])
+	}
+
+	updateResp := testResourceUpdate(t, r, settings(true), settings(false))
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", updateResp.Diagnostics.Errors())
+	}
+	if server.section("dns")["ignoreLocalhost"] != false {
+		t.Errorf("Expected dns.ignoreLocalhost to be disabled again, got %v", server.section("dns")["ignoreLocalhost"])
+	}
+
+	var ignoreLocalhost types.Bool
+	updateResp.State.GetAttribute(context.Background(), path.Root("ignore_localhost"), &ignoreLocalhost)
+	if ignoreLocalhost.ValueBool() {
+		t.Errorf("Expected ignore_localhost to be false in state, got %s", ignoreLocalhost)
+	}
+}
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DomainResource{}
+var _ resource.ResourceWithImportState = &DomainResource{}
+
+func NewDomainResource() resource.Resource {
+	return &DomainResource{}
+}
+
+type DomainResource struct {
+	client *PiholeClient
+}
+
+type DomainResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Domain  types.String `tfsdk:"domain"`
+	Type    types.String `tfsdk:"type"`
+	Kind    types.String `tfsdk:"kind"`
+	Comment types.String `tfsdk:"comment"`
+	Groups  types.Set    `tfsdk:"groups"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+
+	DateAdded    types.Int64 `tfsdk:"date_added"`
+	DateModified types.Int64 `tfsdk:"date_modified"`
+}
+
+func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an entry of Pi-hole's allow or deny lists, either an exact domain or a regular expression. " +
+			"An allow-regex can be used to carve exceptions out of a broad deny-regex.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Domain identifier in the format `type/kind/domain`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain name, or regular expression if `kind` is `regex` (e.g. `^ads\\.example\\.com$`)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "List type: `allow` or `deny`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "deny"),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Entry kind: `exact` or `regex`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("exact", "regex"),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Comment for the entry",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"groups": schema.SetAttribute{
+				MarkdownDescription: "IDs of the groups the entry applies to. When not set, the provider's `default_group_ids` " +
+					"are used, or Pi-hole's Default group (`0`) if those aren't configured either.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the entry is enabled",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"date_added": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Time the entry was added to Pi-hole, as a Unix timestamp",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_modified": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Time the entry was last modified in Pi-hole, as a Unix timestamp",
+			},
+		},
+	}
+}
+
+func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := domainRequestFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Explicitly configured groups win over the provider's default_group_ids
+	if data.Groups.IsUnknown() && len(r.client.Config.DefaultGroupIDs) > 0 {
+		request.Groups = r.client.Config.DefaultGroupIDs
+	}
+
+	err := r.client.CreateDomain(data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
+		return
+	}
+	r.scheduleGravityUpdate(ctx, &resp.Diagnostics)
+
+	if found, err := r.readInto(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	} else if !found {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Domain '%s' was not found after it was created", data.Domain.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.readInto(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := domainRequestFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateDomain(data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain, got error: %s", err))
+		return
+	}
+	r.scheduleGravityUpdate(ctx, &resp.Diagnostics)
+
+	if _, err := r.readInto(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDomain(data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain, got error: %s", err))
+		return
+	}
+	r.scheduleGravityUpdate(ctx, &resp.Diagnostics)
+}
+
+// scheduleGravityUpdate runs the provider's auto_gravity update. The domain change itself succeeded, so a failed
+// update is only a warning that keeps the state in line with Pi-hole.
+func (r *DomainResource) scheduleGravityUpdate(ctx context.Context, diags *diag.Diagnostics) {
+	if err := r.client.ScheduleGravityUpdate(ctx); err != nil {
+		diags.AddWarning("Gravity Update Failed",
+			fmt.Sprintf("The domain was changed, but the automatic gravity update failed: %s. "+
+				"Gravity is updated again with the next blocklist change, or run a gravity update manually.", err))
+	}
+}
+
+func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Regex patterns may contain slashes themselves, so only the first two separate the ID parts
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the format 'type/kind/domain' (e.g. 'allow/regex/^ads\\.example\\.com$'), got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// readInto reconciles the model with the domain list entry stored in Pi-hole
+func (r *DomainResource) readInto(ctx context.Context, data *DomainResourceModel) (bool, error) {
+	domain, err := r.client.GetDomain(data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString())
+	if err != nil {
+		return false, err
+	}
+
+	if domain == nil {
+		return false, nil
+	}
+
+	groups, diags := types.SetValueFrom(ctx, types.Int64Type, domain.Groups)
+	if diags.HasError() {
+		return false, fmt.Errorf("unable to convert groups: %v", diags.Errors())
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", domain.Type, domain.Kind, domain.Domain))
+	data.Comment = types.StringValue(domain.Comment)
+	data.Groups = groups
+	data.Enabled = types.BoolValue(domain.Enabled)
+	data.DateAdded = types.Int64Value(domain.DateAdded)
+	data.DateModified = types.Int64Value(domain.DateModified)
+
+	return true, nil
+}
+
+// domainRequestFromModel builds the writable fields of a domain list entry from the planned model
+func domainRequestFromModel(ctx context.Context, data DomainResourceModel) (DomainRequest, diag.Diagnostics) {
+	request := DomainRequest{
+		Comment: data.Comment.ValueString(),
+		Enabled: data.Enabled.ValueBool(),
+	}
+
+	var diags diag.Diagnostics
+	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
+		diags = data.Groups.ElementsAs(ctx, &request.Groups, false)
+	}
+
+	return request, diags
+}
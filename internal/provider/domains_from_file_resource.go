@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DomainsFromFileResource{}
+var _ resource.ResourceWithModifyPlan = &DomainsFromFileResource{}
+
+func NewDomainsFromFileResource() resource.Resource {
+	return &DomainsFromFileResource{}
+}
+
+type DomainsFromFileResource struct {
+	client *PiholeClient
+}
+
+type DomainsFromFileResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Path    types.String `tfsdk:"path"`
+	Type    types.String `tfsdk:"type"`
+	Kind    types.String `tfsdk:"kind"`
+	Comment types.String `tfsdk:"comment"`
+	Domains types.Set    `tfsdk:"domains"`
+}
+
+func (r *DomainsFromFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains_from_file"
+}
+
+func (r *DomainsFromFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the entries of an allow or deny list from a local text file with one domain, or regular " +
+			"expression, per line. Blank lines and `#` comments are skipped. The file is read at plan time, so changes to it " +
+			"show up as changes of `domains`. Entries are added and removed in batches. Deleting this resource removes only " +
+			"the entries it manages.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the format `type/kind/path`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the file to read the entries from",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "List type: `allow` or `deny`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "deny"),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Entry kind: `exact` or `regex` (default: `exact`)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("exact"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("exact", "regex"),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Comment for the created entries. Changing it re-creates the entries",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "The entries read from the file",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *DomainsFromFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DomainsFromFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to read on destroy, or while the path is only known at apply time
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DomainsFromFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Path.IsUnknown() {
+		return
+	}
+
+	domains, err := readDomainsFile(plan.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid Domains File", err.Error())
+		return
+	}
+
+	value, diags := types.SetValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("domains"), value)...)
+}
+
+func (r *DomainsFromFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainsFromFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := r.plannedDomains(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data, desired, nil, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domains, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", data.Type.ValueString(), data.Kind.ValueString(), data.Path.ValueString()))
+	resp.Diagnostics.Append(r.readInto(ctx, &data, desired)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainsFromFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainsFromFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managed []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &managed, false)...)
+	resp.Diagnostics.Append(r.readInto(ctx, &data, managed)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainsFromFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DomainsFromFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DomainsFromFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := r.plannedDomains(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	var previous []string
+	resp.Diagnostics.Append(state.Domains.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Entries that were dropped from the file are no longer managed and get removed
+	if err := r.apply(ctx, data, desired, previous, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domains, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", data.Type.ValueString(), data.Kind.ValueString(), data.Path.ValueString()))
+	resp.Diagnostics.Append(r.readInto(ctx, &data, desired)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainsFromFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainsFromFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	var managed []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &managed, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data, nil, managed, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domains, got error: %s", err))
+		return
+	}
+}
+
+// plannedDomains returns the entries planned for the resource, reading the file if its path was unknown at plan time
+func (r *DomainsFromFileResource) plannedDomains(ctx context.Context, data DomainsFromFileResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.Domains.IsUnknown() {
+		domains, err := readDomainsFile(data.Path.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("path"), "Invalid Domains File", err.Error())
+		}
+		return domains, diags
+	}
+
+	var domains []string
+	diags.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+	slices.Sort(domains)
+	return domains, diags
+}
+
+// apply adds the desired entries and removes the removed ones, followed by the provider's auto_gravity update.
+// The entries are changed even if gravity fails to update, so that is reported as a warning.
+func (r *DomainsFromFileResource) apply(ctx context.Context, data DomainsFromFileResourceModel, desired, removed []string, diags *diag.Diagnostics) error {
+	request := DomainRequest{
+		Comment: data.Comment.ValueString(),
+		Groups:  r.client.Config.DefaultGroupIDs,
+		Enabled: true,
+	}
+
+	changed, err := r.client.ApplyDomains(ctx, data.Type.ValueString(), data.Kind.ValueString(), desired, removed, request)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := r.client.ScheduleGravityUpdate(ctx); err != nil {
+		diags.AddWarning("Gravity Update Failed",
+			fmt.Sprintf("The domains were changed, but the automatic gravity update failed: %s. "+
+				"Gravity is updated again with the next blocklist change, or run a gravity update manually.", err))
+	}
+
+	return nil
+}
+
+// readInto sets domains to the managed entries that exist in Pi-hole. Entries removed outside of Terraform drop
+// out of the set, so the next plan adds them again.
+func (r *DomainsFromFileResource) readInto(ctx context.Context, data *DomainsFromFileResourceModel, managed []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domains, err := r.client.GetDomains(data.Type.ValueString(), data.Kind.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read domains, got error: %s", err))
+		return diags
+	}
+
+	current := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		current[domain.Domain] = true
+	}
+
+	existing := make([]string, 0, len(managed))
+	for _, domain := range managed {
+		if current[domain] {
+			existing = append(existing, domain)
+		}
+	}
+
+	value, setDiags := types.SetValueFrom(ctx, types.StringType, existing)
+	diags.Append(setDiags...)
+	data.Domains = value
+
+	return diags
+}
+
+// readDomainsFile reads the entries of a domains file, see parseDomainsFile
+func readDomainsFile(name string) ([]string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read domains file: %w", err)
+	}
+
+	return parseDomainsFile(string(content))
+}
+
+// parseDomainsFile returns the sorted, unique entries of a file with one domain or regex per line. Blank lines, lines
+// starting with '#' and comments separated from the entry by whitespace are skipped.
+func parseDomainsFile(content string) ([]string, error) {
+	seen := make(map[string]bool)
+	var domains []string
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// A '#' inside a regex is part of the pattern, only one after whitespace starts a comment
+		if index := strings.IndexAny(line, " \t"); index >= 0 {
+			if rest := strings.TrimSpace(line[index:]); !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: expected a single domain per line, got '%s'", i+1, line)
+			}
+			line = line[:index]
+		}
+
+		if !seen[line] {
+			seen[line] = true
+			domains = append(domains, line)
+		}
+	}
+
+	slices.Sort(domains)
+	return domains, nil
+}
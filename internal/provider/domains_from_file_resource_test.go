@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// writeDomainsFile writes content to a file in a temporary directory and returns its path
+func writeDomainsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "domains.txt")
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write domains file: %v", err)
+	}
+	return name
+}
+
+// storedDomains returns the entries of a domain list in the fake, sorted
+func storedDomains(server *fakePihole, domainType, kind string) []string {
+	var domains []string
+	for _, domain := range server.domainList(domainType, kind) {
+		domains = append(domains, domain.Domain)
+	}
+	slices.Sort(domains)
+	return domains
+}
+
+func TestDomainsFromFileResource_Metadata(t *testing.T) {
+	r := NewDomainsFromFileResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_domains_from_file" {
+		t.Errorf("Expected TypeName to be 'pihole_domains_from_file', got '%s'", resp.TypeName)
+	}
+}
+
+func TestParseDomainsFile(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    []string
+		expectError bool
+	}{
+		{"one per line", "ads.example.com\ntracker.example.net\n", []string{"ads.example.com", "tracker.example.net"}, false},
+		{"comments and blank lines", "# Trackers\n\n  ads.example.com  \n\t\n# end\n", []string{"ads.example.com"}, false},
+		{"inline comment", "ads.example.com # added 2024\nmetrics.example.com\t#old\n", []string{"ads.example.com", "metrics.example.com"}, false},
+		{"windows line endings", "ads.example.com\r\nmetrics.example.com\r\n", []string{"ads.example.com", "metrics.example.com"}, false},
+		{"duplicates", "ads.example.com\nads.example.com\n", []string{"ads.example.com"}, false},
+		{"hash inside regex", `^ads[0-9]+\.example\.com/(\?|#).*$`, []string{`^ads[0-9]+\.example\.com/(\?|#).*$`}, false},
+		{"empty file", "# nothing yet\n", nil, false},
+		{"hosts format", "0.0.0.0 ads.example.com\n", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			domains, err := parseDomainsFile(tc.content)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", domains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(domains, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, domains)
+			}
+		})
+	}
+}
+
+func TestDomainsFromFileResource_ModifyPlanReadsFile(t *testing.T) {
+	r := NewDomainsFromFileResource()
+
+	name := writeDomainsFile(t, "# Trackers\nads.example.com\n\ntracker.example.net # since 2024\n")
+	resp := testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"path":    tftypes.NewValue(tftypes.String, name),
+		"type":    tftypes.NewValue(tftypes.String, "deny"),
+		"kind":    tftypes.NewValue(tftypes.String, "exact"),
+		"comment": tftypes.NewValue(tftypes.String, ""),
+		"domains": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var domains []string
+	resp.Plan.GetAttribute(context.Background(), path.Root("domains"), &domains)
+	slices.Sort(domains)
+	if expected := []string{"ads.example.com", "tracker.example.net"}; !slices.Equal(domains, expected) {
+		t.Errorf("Expected the planned domains to be %v, got %v", expected, domains)
+	}
+
+	resp = testResourceModifyPlan(t, r, nil, map[string]tftypes.Value{
+		"path":    tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing.txt")),
+		"type":    tftypes.NewValue(tftypes.String, "deny"),
+		"kind":    tftypes.NewValue(tftypes.String, "exact"),
+		"comment": tftypes.NewValue(tftypes.String, ""),
+		"domains": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+	})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Domains File" {
+		t.Errorf("Expected 'Invalid Domains File' for a missing file, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDomainsFromFileResource_Lifecycle(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	// An entry managed elsewhere must survive all changes of the resource
+	if err := client.CreateDomain("deny", "exact", "unrelated.example.org", DomainRequest{Enabled: true}); err != nil {
+		t.Fatalf("Failed to create domain: %v", err)
+	}
+
+	r := NewDomainsFromFileResource()
+	testConfigureResource(t, r, client)
+
+	name := writeDomainsFile(t, "# Trackers\nads.example.com\n\ntracker.example.net # since 2024\nmetrics.example.com\n")
+	domains := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+	}
+	model := func(domains tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.String, "deny/exact/"+name),
+			"path":    tftypes.NewValue(tftypes.String, name),
+			"type":    tftypes.NewValue(tftypes.String, "deny"),
+			"kind":    tftypes.NewValue(tftypes.String, "exact"),
+			"comment": tftypes.NewValue(tftypes.String, "migrated"),
+			"domains": domains,
+		}
+	}
+
+	// The path is only known at apply time, so Create reads the file itself
+	created := model(tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue))
+	createResp := testResourceCreate(t, r, created)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+
+	expected := []string{"ads.example.com", "metrics.example.com", "tracker.example.net", "unrelated.example.org"}
+	if stored := storedDomains(server, "deny", "exact"); !slices.Equal(stored, expected) {
+		t.Errorf("Expected every non-comment line to become a deny entry, got %v", stored)
+	}
+	for _, domain := range server.domainList("deny", "exact") {
+		if domain.Domain != "unrelated.example.org" && domain.Comment != "migrated" {
+			t.Errorf("Expected '%s' to get the comment 'migrated', got '%s'", domain.Domain, domain.Comment)
+		}
+	}
+	// One request for the unrelated entry, one for all entries of the file
+	if count := server.requestCount("POST /api/domains/deny/exact"); count != 2 {
+		t.Errorf("Expected the entries to be created with a single request, got %d requests in total", count)
+	}
+
+	var managed []string
+	createResp.State.GetAttribute(context.Background(), path.Root("domains"), &managed)
+	slices.Sort(managed)
+	if !slices.Equal(managed, expected[:3]) {
+		t.Errorf("Expected the state to hold the entries of the file, got %v", managed)
+	}
+
+	// Dropping a line removes its entry, adding one creates it
+	prior := model(domains("ads.example.com", "metrics.example.com", "tracker.example.net"))
+	updateResp := testResourceUpdate(t, r, prior, model(domains("ads.example.com", "tracker.example.net", "beacon.example.com")))
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", updateResp.Diagnostics.Errors())
+	}
+
+	expected = []string{"ads.example.com", "beacon.example.com", "tracker.example.net", "unrelated.example.org"}
+	if stored := storedDomains(server, "deny", "exact"); !slices.Equal(stored, expected) {
+		t.Errorf("Expected the entries to follow the file, got %v", stored)
+	}
+
+	// An entry removed outside of Terraform drops out of the state
+	if err := client.DeleteDomain("deny", "exact", "beacon.example.com"); err != nil {
+		t.Fatalf("Failed to delete domain: %v", err)
+	}
+	current := model(domains("ads.example.com", "tracker.example.net", "beacon.example.com"))
+	readResp := testResourceRead(t, r, current)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", readResp.Diagnostics.Errors())
+	}
+	managed = nil
+	readResp.State.GetAttribute(context.Background(), path.Root("domains"), &managed)
+	slices.Sort(managed)
+	if expected := []string{"ads.example.com", "tracker.example.net"}; !slices.Equal(managed, expected) {
+		t.Errorf("Expected the removed entry to drop out of the state, got %v", managed)
+	}
+
+	deleteResp := testResourceDelete(t, r, model(domains("ads.example.com", "tracker.example.net")))
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", deleteResp.Diagnostics.Errors())
+	}
+	if stored := storedDomains(server, "deny", "exact"); !slices.Equal(stored, []string{"unrelated.example.org"}) {
+		t.Errorf("Expected only the unrelated entry to remain, got %v", stored)
+	}
+	if count := server.requestCount("POST /api/domains:batchDelete"); count != 2 {
+		t.Errorf("Expected one batch delete per change, got %d", count)
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EDNSConfigResource{}
+
+func NewEDNSConfigResource() resource.Resource {
+	return &EDNSConfigResource{}
+}
+
+type EDNSConfigResource struct {
+	client *PiholeClient
+}
+
+type EDNSConfigResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	EDNS0ECS types.Bool   `tfsdk:"edns0_ecs"`
+}
+
+func (r *EDNSConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_edns_config"
+}
+
+func (r *EDNSConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages whether FTL uses EDNS Client Subnet (ECS) information to identify clients " +
+			"(`dns.EDNS0ECS`). With it, Pi-hole sees the real clients behind a router or DNS forwarder that passes " +
+			"their subnet on, instead of only the forwarder. " +
+			"Deleting this resource restores Pi-hole's default of using ECS information.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "EDNS configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"edns0_ecs": schema.BoolAttribute{
+				MarkdownDescription: "Whether FTL takes the client of a query from its EDNS Client Subnet option, if present",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *EDNSConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EDNSConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"EDNS0ECS": data.EDNS0ECS.ValueBool()}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set EDNS configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read EDNS configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EDNSConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data EDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read EDNS configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EDNSConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EDNSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"EDNS0ECS": data.EDNS0ECS.ValueBool()}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update EDNS configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read EDNS configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EDNSConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole default rather than leaving ECS information ignored
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"EDNS0ECS": true}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset EDNS configuration, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the EDNS setting currently active in Pi-hole
+func (r *EDNSConfigResource) readInto(data *EDNSConfigResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	ecs, ok := dnsConfig["EDNS0ECS"].(bool)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.EDNS0ECS: %v", dnsConfig["EDNS0ECS"])
+	}
+
+	data.ID = types.StringValue("edns_config")
+	data.EDNS0ECS = types.BoolValue(ecs)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ednsConfigFakeSections is a dns section with ECS information used or ignored
+func ednsConfigFakeSections(ecs bool) map[string]map[string]interface{} {
+	return settingsFakeSections("dns", map[string]interface{}{
+		"EDNS0ECS": ecs,
+	})
+}
+
+func TestEDNSConfigResource_Metadata(t *testing.T) {
+	r := NewEDNSConfigResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_edns_config" {
+		t.Errorf("Expected TypeName to be 'pihole_edns_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestEDNSConfigResource_EnableECS(t *testing.T) {
+	server := newFakePihole(ednsConfigFakeSections(false))
+	defer server.Close()
+
+	r := NewEDNSConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"edns0_ecs": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if server.section("dns")["EDNS0ECS"] != true {
+		t.Errorf("Expected dns.EDNS0ECS to be enabled, got %v", server.section("dns")["EDNS0ECS"])
+	}
+
+	var ecs types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("edns0_ecs"), &ecs)
+	if !ecs.ValueBool() {
+		t.Errorf("Expected edns0_ecs to be true in state, got %s", ecs)
+	}
+}
+
+func TestEDNSConfigResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(ednsConfigFakeSections(false))
+	defer server.Close()
+
+	r := NewEDNSConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":        tftypes.NewValue(tftypes.String, "edns_config"),
+		"edns0_ecs": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var ecs types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("edns0_ecs"), &ecs)
+	if ecs.ValueBool() {
+		t.Error("Expected state to reflect that ECS was disabled in Pi-hole")
+	}
+}
@@ -0,0 +1,813 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakePihole is a stateful in-memory Pi-hole v6 API for unit tests. Unlike a server with canned
+// responses, changes made through PUT, PATCH and DELETE are reflected in subsequent GETs, so
+// create-then-read round-trips can be verified without a real Pi-hole.
+type fakePihole struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	config       map[string]interface{}
+	domains      []Domain
+	lists        []List
+	nextDomainID int64
+	clock        int64
+	requests     map[string]int
+	requestLog   []string
+	sessions     []Session
+
+	// hostsHistory holds dns.hosts after every write, to check the states a change went through
+	hostsHistory [][]string
+}
+
+// newFakePihole starts a fake Pi-hole serving the given configuration sections. Values are
+// normalized through JSON, so numbers are stored as float64 and lists as []interface{} like
+// they would be decoded from a real Pi-hole.
+func newFakePihole(sections map[string]map[string]interface{}) *fakePihole {
+	f := &fakePihole{
+		config:   make(map[string]interface{}),
+		requests: make(map[string]int),
+		// Domain timestamps advance by one second per write, so tests can tell them apart
+		clock: 1700000000,
+	}
+
+	encoded, _ := json.Marshal(sections)
+	json.Unmarshal(encoded, &f.config)
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// defaultFakePiholeSections is the configuration the shared unit tests run against
+func defaultFakePiholeSections() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"hosts": []string{
+				"192.168.1.100 test.example.com",
+				"192.168.1.101 server.example.com",
+			},
+			"cnameRecords": []string{
+				"www.example.com,example.com",
+				"mail.example.com,server.example.com",
+			},
+			"domain": "lan",
+		},
+		"webserver": {
+			"api": map[string]interface{}{"app_sudo": true},
+		},
+	}
+}
+
+// createMockPiholeServer starts a fake Pi-hole with the default test configuration
+func createMockPiholeServer() *fakePihole {
+	return newFakePihole(defaultFakePiholeSections())
+}
+
+func (f *fakePihole) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.Method+" "+r.URL.Path]++
+	f.requestLog = append(f.requestLog, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/api/auth" {
+		f.handleAuth(w, r)
+		return
+	}
+
+	if r.Header.Get("X-FTL-SID") != "mock-session-id" {
+		f.writeError(w, http.StatusUnauthorized, "unauthorized", "No valid session")
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/api/config" && r.Method == "GET":
+		json.NewEncoder(w).Encode(map[string]interface{}{"config": f.config})
+	case r.URL.Path == "/api/config" && r.Method == "PATCH":
+		f.handlePatch(w, r)
+	case strings.HasPrefix(r.URL.Path, "/api/domains/"):
+		f.handleDomains(w, r)
+	case r.URL.Path == "/api/domains:batchDelete" && r.Method == "POST":
+		f.handleDeleteDomains(w, r)
+	case r.URL.Path == "/api/lists" && r.Method == "GET":
+		json.NewEncoder(w).Encode(map[string]interface{}{"lists": f.lists})
+	case r.URL.Path == "/api/lists" && r.Method == "POST":
+		f.handleCreateLists(w, r)
+	case r.URL.Path == "/api/lists:batchDelete" && r.Method == "POST":
+		f.handleDeleteLists(w, r)
+	case strings.HasPrefix(r.URL.Path, "/api/lists/") && r.Method == "PUT":
+		f.handleUpdateList(w, r)
+	case r.URL.Path == "/api/action/gravity" && r.Method == "POST":
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("  [✓] Done.\n"))
+	case r.URL.Path == "/api/action/restartdns" && r.Method == "POST":
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "restarting"})
+	case r.URL.Path == "/api/auth/sessions" && r.Method == "GET":
+		json.NewEncoder(w).Encode(map[string]interface{}{"sessions": f.sessions})
+	case strings.HasPrefix(r.URL.Path, "/api/auth/session/") && r.Method == "DELETE":
+		f.handleDeleteSession(w, strings.TrimPrefix(r.URL.Path, "/api/auth/session/"))
+	case strings.HasPrefix(r.URL.Path, "/api/config/"):
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/config/"), "/")
+		switch r.Method {
+		case "GET":
+			f.handleGet(w, parts)
+		case "PUT":
+			f.handlePut(w, r, parts)
+		case "DELETE":
+			f.handleDelete(w, parts)
+		default:
+			f.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		}
+	default:
+		f.writeError(w, http.StatusNotFound, "not_found", "Not found")
+	}
+
+	if r.Method != "GET" {
+		hosts, _ := f.lookupList([]string{"dns", "hosts"})
+		snapshot := make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			if line, ok := host.(string); ok {
+				snapshot = append(snapshot, line)
+			}
+		}
+		f.hostsHistory = append(f.hostsHistory, snapshot)
+	}
+}
+
+func (f *fakePihole) handleAuth(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"session": map[string]interface{}{
+				"valid":    true,
+				"totp":     false,
+				"sid":      "mock-session-id",
+				"validity": 1800,
+				"message":  "success",
+				"csrf":     "mock-csrf-token",
+			},
+			"took": 0.001,
+		})
+	case "DELETE":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+// handleDeleteSession revokes the session with the given ID
+func (f *fakePihole) handleDeleteSession(w http.ResponseWriter, id string) {
+	for i, session := range f.sessions {
+		if fmt.Sprint(session.ID) == id {
+			f.sessions = append(f.sessions[:i], f.sessions[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	f.writeError(w, http.StatusNotFound, "not_found", "Session not found")
+}
+
+// handleGet returns the requested subtree wrapped in its parent keys, e.g. {"config":{"dns":{"hosts":[...]}}}
+func (f *fakePihole) handleGet(w http.ResponseWriter, parts []string) {
+	value, exists := f.lookup(parts)
+	if !exists {
+		f.writeError(w, http.StatusNotFound, "not_found", "Invalid path")
+		return
+	}
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		value = map[string]interface{}{parts[i]: value}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"config": value})
+}
+
+// handlePut adds an item to a list (PUT /api/config/dns/hosts/{item}) or, when a body is sent,
+// replaces the value at the path (PUT /api/config/webserver)
+func (f *fakePihole) handlePut(w http.ResponseWriter, r *http.Request, parts []string) {
+	body, _ := io.ReadAll(r.Body)
+
+	if len(body) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+			return
+		}
+
+		parent, exists := f.lookupObject(parts[:len(parts)-1])
+		if !exists {
+			f.writeError(w, http.StatusNotFound, "not_found", "Invalid path")
+			return
+		}
+		parent[parts[len(parts)-1]] = value
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"config": value})
+		return
+	}
+
+	if len(parts) < 2 {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Missing item")
+		return
+	}
+
+	listPath, item := parts[:len(parts)-1], parts[len(parts)-1]
+	list, exists := f.lookupList(listPath)
+	if !exists {
+		f.writeError(w, http.StatusNotFound, "not_found", "Invalid path")
+		return
+	}
+
+	for _, existing := range list {
+		if existing == item {
+			f.writeError(w, http.StatusBadRequest, "bad_request", "Item already present")
+			return
+		}
+	}
+
+	f.storeList(listPath, append(list, item))
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+}
+
+// handleDelete removes an item from a list (DELETE /api/config/dns/hosts/{item})
+func (f *fakePihole) handleDelete(w http.ResponseWriter, parts []string) {
+	if len(parts) < 2 {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Missing item")
+		return
+	}
+
+	listPath, item := parts[:len(parts)-1], parts[len(parts)-1]
+	list, exists := f.lookupList(listPath)
+	if !exists {
+		f.writeError(w, http.StatusNotFound, "not_found", "Invalid path")
+		return
+	}
+
+	for i, existing := range list {
+		if existing == item {
+			remaining := append(append([]interface{}{}, list[:i]...), list[i+1:]...)
+			f.storeList(listPath, remaining)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	f.writeError(w, http.StatusNotFound, "not_found", "Item not found")
+}
+
+// handlePatch deep-merges {"config":{...}} into the configuration like Pi-hole does
+func (f *fakePihole) handlePatch(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+		return
+	}
+
+	mergeConfigValues(f.config, payload.Config)
+	json.NewEncoder(w).Encode(map[string]interface{}{"config": payload.Config})
+}
+
+// handleDomains serves /api/domains/{type}/{kind}[/{domain}]. The domain segment is taken from the
+// escaped path and unescaped once, so a regex containing '/' or an escaped character round-trips exactly.
+func (f *fakePihole) handleDomains(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.EscapedPath(), "/api/domains/"), "/", 3)
+	if len(parts) < 2 {
+		f.writeError(w, http.StatusNotFound, "not_found", "Invalid path")
+		return
+	}
+
+	domainType, kind := parts[0], parts[1]
+	var domain string
+	if len(parts) == 3 {
+		var err error
+		if domain, err = url.PathUnescape(parts[2]); err != nil {
+			f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid domain")
+			return
+		}
+	}
+
+	index := -1
+	for i, d := range f.domains {
+		if d.Type == domainType && d.Kind == kind && d.Domain == domain {
+			index = i
+		}
+	}
+
+	switch r.Method {
+	case "GET":
+		matches := []Domain{}
+		for _, d := range f.domains {
+			if d.Type == domainType && d.Kind == kind && (domain == "" || d.Domain == domain) {
+				matches = append(matches, d)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"domains": matches})
+	case "POST":
+		// Like Pi-hole, the domain is either a single string or a list of them
+		var payload struct {
+			Domain json.RawMessage `json:"domain"`
+			DomainRequest
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+			return
+		}
+		var items []string
+		if err := json.Unmarshal(payload.Domain, &items); err != nil {
+			var item string
+			if err := json.Unmarshal(payload.Domain, &item); err != nil {
+				f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid domain")
+				return
+			}
+			items = []string{item}
+		}
+
+		succeeded, failed := []interface{}{}, []interface{}{}
+		for _, item := range items {
+			exists := false
+			for _, d := range f.domains {
+				if d.Type == domainType && d.Kind == kind && d.Domain == item {
+					exists = true
+				}
+			}
+			if exists {
+				failed = append(failed, map[string]interface{}{"item": item, "error": "UNIQUE constraint failed: domainlist.domain, domainlist.type"})
+				continue
+			}
+
+			f.nextDomainID++
+			f.clock++
+			groups := payload.Groups
+			if len(groups) == 0 {
+				groups = []int64{0}
+			}
+			f.domains = append(f.domains, Domain{
+				ID:           f.nextDomainID,
+				Domain:       item,
+				Unicode:      item,
+				Type:         domainType,
+				Kind:         kind,
+				Comment:      payload.Comment,
+				Groups:       groups,
+				Enabled:      payload.Enabled,
+				DateAdded:    f.clock,
+				DateModified: f.clock,
+			})
+			succeeded = append(succeeded, map[string]interface{}{"item": item})
+		}
+		processed := map[string]interface{}{"success": succeeded, "errors": failed}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"processed": processed})
+	case "PUT":
+		if index < 0 {
+			f.writeError(w, http.StatusNotFound, "not_found", "Domain not found")
+			return
+		}
+
+		var payload DomainRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+			return
+		}
+
+		f.clock++
+		f.domains[index].Comment = payload.Comment
+		f.domains[index].Enabled = payload.Enabled
+		f.domains[index].DateModified = f.clock
+		if len(payload.Groups) > 0 {
+			f.domains[index].Groups = payload.Groups
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"domains": []Domain{f.domains[index]}})
+	case "DELETE":
+		if index < 0 {
+			f.writeError(w, http.StatusNotFound, "not_found", "Domain not found")
+			return
+		}
+
+		f.domains = append(f.domains[:index], f.domains[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+// handleDeleteDomains serves POST /api/domains:batchDelete
+func (f *fakePihole) handleDeleteDomains(w http.ResponseWriter, r *http.Request) {
+	var payload []struct {
+		Item string `json:"item"`
+		Type string `json:"type"`
+		Kind string `json:"kind"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+		return
+	}
+
+	remaining := f.domains[:0]
+	for _, domain := range f.domains {
+		deleted := false
+		for _, item := range payload {
+			if domain.Domain == item.Item && domain.Type == item.Type && domain.Kind == item.Kind {
+				deleted = true
+			}
+		}
+		if !deleted {
+			remaining = append(remaining, domain)
+		}
+	}
+	f.domains = remaining
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleUpdateList serves PUT /api/lists/{address}?type={type}. The address is taken from the escaped
+// path, as list URLs contain '/' themselves.
+func (f *fakePihole) handleUpdateList(w http.ResponseWriter, r *http.Request) {
+	address, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/lists/"))
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid address")
+		return
+	}
+
+	var payload struct {
+		Comment string  `json:"comment"`
+		Groups  []int64 `json:"groups"`
+		Enabled bool    `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+		return
+	}
+
+	for i, list := range f.lists {
+		if list.Address == address && list.Type == r.URL.Query().Get("type") {
+			f.lists[i].Comment = payload.Comment
+			f.lists[i].Enabled = payload.Enabled
+			if len(payload.Groups) > 0 {
+				f.lists[i].Groups = payload.Groups
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"lists": []List{f.lists[i]}})
+			return
+		}
+	}
+
+	f.writeError(w, http.StatusNotFound, "not_found", "List not found")
+}
+
+// handleCreateLists serves POST /api/lists?type={type}, which takes one address or a list of addresses
+func (f *fakePihole) handleCreateLists(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Address []string `json:"address"`
+		Comment string   `json:"comment"`
+		Enabled bool     `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+		return
+	}
+
+	listType := r.URL.Query().Get("type")
+	var created []List
+	for _, address := range payload.Address {
+		f.clock++
+		list := List{
+			ID:           int64(len(f.lists) + 1),
+			Address:      address,
+			Type:         listType,
+			Comment:      payload.Comment,
+			Groups:       []int64{0},
+			Enabled:      payload.Enabled,
+			DateAdded:    f.clock,
+			DateModified: f.clock,
+		}
+		f.lists = append(f.lists, list)
+		created = append(created, list)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{"lists": created})
+}
+
+// handleDeleteLists serves POST /api/lists:batchDelete
+func (f *fakePihole) handleDeleteLists(w http.ResponseWriter, r *http.Request) {
+	var payload []struct {
+		Item string `json:"item"`
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON")
+		return
+	}
+
+	remaining := f.lists[:0]
+	for _, list := range f.lists {
+		deleted := false
+		for _, item := range payload {
+			if list.Address == item.Item && list.Type == item.Type {
+				deleted = true
+			}
+		}
+		if !deleted {
+			remaining = append(remaining, list)
+		}
+	}
+	f.lists = remaining
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setLists replaces the subscribed lists served by the fake
+func (f *fakePihole) setLists(lists ...List) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lists = lists
+}
+
+// listsEnabled returns the enabled flag of every list by address
+func (f *fakePihole) listsEnabled() map[string]bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	enabled := make(map[string]bool, len(f.lists))
+	for _, list := range f.lists {
+		enabled[list.Address] = list.Enabled
+	}
+	return enabled
+}
+
+// domainList returns the entries of a domain list as currently stored by the fake
+func (f *fakePihole) domainList(domainType, kind string) []Domain {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var domains []Domain
+	for _, d := range f.domains {
+		if d.Type == domainType && d.Kind == kind {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+func (f *fakePihole) writeError(w http.ResponseWriter, status int, key, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"key": key, "message": message},
+	})
+}
+
+func (f *fakePihole) lookup(parts []string) (interface{}, bool) {
+	var current interface{} = f.config
+	for _, part := range parts {
+		nested, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = nested[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func (f *fakePihole) lookupObject(parts []string) (map[string]interface{}, bool) {
+	value, exists := f.lookup(parts)
+	if !exists {
+		return nil, false
+	}
+	object, ok := value.(map[string]interface{})
+	return object, ok
+}
+
+func (f *fakePihole) lookupList(parts []string) ([]interface{}, bool) {
+	value, exists := f.lookup(parts)
+	if !exists {
+		return nil, false
+	}
+	list, ok := value.([]interface{})
+	return list, ok
+}
+
+func (f *fakePihole) storeList(parts []string, list []interface{}) {
+	parent, _ := f.lookupObject(parts[:len(parts)-1])
+	parent[parts[len(parts)-1]] = list
+}
+
+// mergeConfigValues deep-merges src into dst like Pi-hole does for PATCH requests
+func mergeConfigValues(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeConfigValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// section returns a top-level configuration section as currently stored by the fake
+func (f *fakePihole) section(name string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	section, _ := f.config[name].(map[string]interface{})
+	return section
+}
+
+// setValue changes a value behind the provider's back, e.g. to simulate an edit in the web interface
+func (f *fakePihole) setValue(dottedPath string, value interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	encoded, _ := json.Marshal(value)
+	var normalized interface{}
+	json.Unmarshal(encoded, &normalized)
+
+	setNestedConfigValue(f.config, strings.Split(dottedPath, "."), normalized)
+}
+
+// hosts returns the stored DNS A records in Pi-hole's "IP domain" format
+func (f *fakePihole) hosts() []string {
+	return f.stringList("dns", "hosts")
+}
+
+// hostsSnapshots returns dns.hosts as it was after each write request, oldest first
+func (f *fakePihole) hostsSnapshots() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]string(nil), f.hostsHistory...)
+}
+
+// setSessions replaces the API sessions listed by the fake
+func (f *fakePihole) setSessions(sessions ...Session) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sessions = sessions
+}
+
+// sessionIDs returns the IDs of the sessions the fake still lists
+func (f *fakePihole) sessionIDs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]int64, 0, len(f.sessions))
+	for _, session := range f.sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+// cnameRecords returns the stored CNAME records in Pi-hole's "domain,target" format
+func (f *fakePihole) cnameRecords() []string {
+	return f.stringList("dns", "cnameRecords")
+}
+
+func (f *fakePihole) stringList(parts ...string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	list, _ := f.lookupList(parts)
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// requestCount returns how often the given "METHOD /path" was requested
+func (f *fakePihole) requestCount(methodAndPath string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[methodAndPath]
+}
+
+// writeRequests returns all requests that change state, in the order they were received
+func (f *fakePihole) writeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var writes []string
+	for _, request := range f.requestLog {
+		if !strings.HasPrefix(request, "GET ") && !strings.HasSuffix(request, " /api/auth") {
+			writes = append(writes, request)
+		}
+	}
+	return writes
+}
+
+func TestFakePihole_DNSRecordRoundTrip(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	if err := client.CreateDNSRecord("nas.example.com", "192.168.1.20"); err != nil {
+		t.Fatalf("Failed to create DNS record: %v", err)
+	}
+
+	records, err := client.GetDNSRecords()
+	if err != nil {
+		t.Fatalf("Failed to get DNS records: %v", err)
+	}
+	if len(records) != 1 || records[0].Domain != "nas.example.com" || records[0].IP != "192.168.1.20" {
+		t.Fatalf("Expected the created record to be returned, got %v", records)
+	}
+
+	if err := client.UpdateDNSRecord("nas.example.com", "192.168.1.21"); err != nil {
+		t.Fatalf("Failed to update DNS record: %v", err)
+	}
+	if hosts := server.hosts(); len(hosts) != 1 || hosts[0] != "192.168.1.21 nas.example.com" {
+		t.Errorf("Expected the record to point to the new IP, got %v", hosts)
+	}
+
+	if err := client.DeleteDNSRecord("nas.example.com"); err != nil {
+		t.Fatalf("Failed to delete DNS record: %v", err)
+	}
+	if hosts := server.hosts(); len(hosts) != 0 {
+		t.Errorf("Expected no records after delete, got %v", hosts)
+	}
+}
+
+func TestFakePihole_CNAMERecordRoundTrip(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	if err := client.CreateCNAMERecord("blog.example.com", "server.example.com", 0); err != nil {
+		t.Fatalf("Failed to create CNAME record: %v", err)
+	}
+
+	records, err := client.GetCNAMERecords()
+	if err != nil {
+		t.Fatalf("Failed to get CNAME records: %v", err)
+	}
+	found := false
+	for _, record := range records {
+		if record.Domain == "blog.example.com" && record.Target == "server.example.com" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the created CNAME record to be returned, got %v", records)
+	}
+
+	if err := client.DeleteCNAMERecord("www.example.com"); err != nil {
+		t.Fatalf("Failed to delete CNAME record: %v", err)
+	}
+	for _, record := range server.cnameRecords() {
+		if strings.HasPrefix(record, "www.example.com,") {
+			t.Errorf("Expected www.example.com to be deleted, got %v", server.cnameRecords())
+		}
+	}
+}
+
+func TestFakePihole_RejectsDuplicatesAndUnauthenticatedRequests(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	resp, err := client.makeRequest("PUT", "/api/config/dns/hosts/192.168.1.100%20test.example.com", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected duplicate item to be rejected with 400, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(server.URL + "/api/config/dns/hosts")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected request without session to be rejected with 401, got %d", resp.StatusCode)
+	}
+}
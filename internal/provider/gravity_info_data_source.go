@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GravityInfoDataSource{}
+
+func NewGravityInfoDataSource() datasource.DataSource {
+	return &GravityInfoDataSource{}
+}
+
+type GravityInfoDataSource struct {
+	client *PiholeClient
+}
+
+type GravityInfoDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	DomainsBlocked types.Int64  `tfsdk:"domains_blocked"`
+	LastUpdate     types.Int64  `tfsdk:"last_update"`
+	FileExists     types.Bool   `tfsdk:"file_exists"`
+}
+
+func (d *GravityInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gravity_info"
+}
+
+func (d *GravityInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the state of the gravity database, e.g. to verify the result of a gravity update",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"domains_blocked": schema.Int64Attribute{
+				MarkdownDescription: "Number of domains on the blocklists in the gravity database, `0` if the database is missing",
+				Computed:            true,
+			},
+			"last_update": schema.Int64Attribute{
+				MarkdownDescription: "Time of the last gravity update as a Unix timestamp, `0` if gravity never ran",
+				Computed:            true,
+			},
+			"file_exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether Pi-hole could read the gravity database",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *GravityInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *GravityInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GravityInfoDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := d.client.GetGravityInfo()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read gravity info: "+err.Error())
+		return
+	}
+
+	// A negative count is Pi-hole's marker for a gravity database it couldn't read
+	fileExists := info.DomainsBeingBlocked >= 0
+	domainsBlocked := info.DomainsBeingBlocked
+	if !fileExists {
+		domainsBlocked = 0
+	}
+
+	data.ID = types.StringValue("gravity_info")
+	data.DomainsBlocked = types.Int64Value(domainsBlocked)
+	data.LastUpdate = types.Int64Value(info.LastUpdate)
+	data.FileExists = types.BoolValue(fileExists)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newStatsSummaryServer wraps the fake Pi-hole with a stats summary endpoint answering with the given payload
+func newStatsSummaryServer(t *testing.T, payload string) *httptest.Server {
+	t.Helper()
+
+	fake := createMockPiholeServer()
+	t.Cleanup(fake.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/stats/summary" {
+			fake.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGravityInfoDataSource_Metadata(t *testing.T) {
+	d := NewGravityInfoDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_gravity_info" {
+		t.Errorf("Expected TypeName to be 'pihole_gravity_info', got '%s'", resp.TypeName)
+	}
+}
+
+func TestGravityInfoDataSource_Read(t *testing.T) {
+	testCases := []struct {
+		name           string
+		payload        string
+		domainsBlocked int64
+		lastUpdate     int64
+		fileExists     bool
+	}{
+		{
+			name: "gravity database present",
+			payload: `{
+				"queries": {"total": 1234, "blocked": 56},
+				"clients": {"active": 4, "total": 7},
+				"gravity": {"domains_being_blocked": 121860, "last_update": 1700000000},
+				"took": 0.0004
+			}`,
+			domainsBlocked: 121860,
+			lastUpdate:     1700000000,
+			fileExists:     true,
+		},
+		{
+			name:           "gravity database missing",
+			payload:        `{"gravity": {"domains_being_blocked": -2, "last_update": 0}, "took": 0.0001}`,
+			domainsBlocked: 0,
+			lastUpdate:     0,
+			fileExists:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newStatsSummaryServer(t, tc.payload)
+
+			d := NewGravityInfoDataSource()
+			d.(*GravityInfoDataSource).client = newTestClient(t, server.URL)
+
+			resp := testDataSourceRead(t, d, nil)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			var domainsBlocked, lastUpdate types.Int64
+			var fileExists types.Bool
+			resp.State.GetAttribute(context.Background(), path.Root("domains_blocked"), &domainsBlocked)
+			resp.State.GetAttribute(context.Background(), path.Root("last_update"), &lastUpdate)
+			resp.State.GetAttribute(context.Background(), path.Root("file_exists"), &fileExists)
+
+			if domainsBlocked.ValueInt64() != tc.domainsBlocked {
+				t.Errorf("Expected domains_blocked %d, got %d", tc.domainsBlocked, domainsBlocked.ValueInt64())
+			}
+			if lastUpdate.ValueInt64() != tc.lastUpdate {
+				t.Errorf("Expected last_update %d, got %d", tc.lastUpdate, lastUpdate.ValueInt64())
+			}
+			if fileExists.ValueBool() != tc.fileExists {
+				t.Errorf("Expected file_exists %t, got %t", tc.fileExists, fileExists.ValueBool())
+			}
+		})
+	}
+}
+
+func TestGravityInfoDataSource_MissingGravity(t *testing.T) {
+	server := newStatsSummaryServer(t, `{"queries": {"total": 0}, "took": 0.0001}`)
+
+	d := NewGravityInfoDataSource()
+	d.(*GravityInfoDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error when the summary has no gravity info")
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GravityResource{}
+
+// defaultGravityTimeoutSeconds bounds a gravity update unless the configuration sets a timeout
+const defaultGravityTimeoutSeconds = 600
+
+// autoGravityDebounce is how long a blocklist change waits for further changes before auto_gravity updates gravity
+const autoGravityDebounce = 2 * time.Second
+
+func NewGravityResource() resource.Resource {
+	return &GravityResource{}
+}
+
+type GravityResource struct {
+	client *PiholeClient
+}
+
+type GravityResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+func (r *GravityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gravity"
+}
+
+func (r *GravityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Updates Pi-hole's gravity database, which applies changes to the configured lists. " +
+			"The update runs when the resource is created and whenever `triggers` change. " +
+			"Destroying the resource doesn't change anything in Pi-hole.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the last gravity update (RFC 3339)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause a new gravity update when they change, " +
+					"e.g. the IDs of the lists the update should pick up",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Maximum time in seconds to wait for the gravity update to complete (default: 600)",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultGravityTimeoutSeconds),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *GravityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GravityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GravityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := time.Duration(data.TimeoutSeconds.ValueInt64()) * time.Second
+	gravityCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.UpdateGravity(gravityCtx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			resp.Diagnostics.AddError(
+				"Gravity Update Timed Out",
+				fmt.Sprintf("Pi-hole did not complete the gravity update within %d seconds. The update may still be running "+
+					"in Pi-hole. Increase timeout_seconds for large lists. Error: %s", data.TimeoutSeconds.ValueInt64(), err),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update gravity, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GravityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A gravity update is an action without state in Pi-hole, so there is nothing to refresh
+}
+
+func (r *GravityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GravityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changed triggers replace the resource, so only timeout_seconds can change here
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GravityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The gravity database stays as it is, the resource is only removed from state
+}
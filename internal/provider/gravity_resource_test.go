@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// newGravityServer wraps the fake Pi-hole with a gravity endpoint that streams some output and only
+// finishes once release is closed, like a long-running gravity update
+func newGravityServer(t *testing.T, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+
+	fake := createMockPiholeServer()
+	t.Cleanup(fake.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/action/gravity" {
+			fake.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("  [i] Neutrino emissions detected...\n"))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-release:
+			w.Write([]byte("  [✓] Done.\n"))
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGravityResource_Metadata(t *testing.T) {
+	r := NewGravityResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_gravity" {
+		t.Errorf("Expected TypeName to be 'pihole_gravity', got '%s'", resp.TypeName)
+	}
+}
+
+func TestPiholeClient_UpdateGravity(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	server := newGravityServer(t, release)
+
+	client := newTestClient(t, server.URL)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if err := client.UpdateGravity(ctx); err != nil {
+		t.Fatalf("Expected gravity update to succeed, got: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	var progress []string
+	for _, entry := range entries {
+		progress = append(progress, entry["@message"].(string))
+	}
+	expected := []string{"Gravity update: [i] Neutrino emissions detected...", "Gravity update: [✓] Done."}
+	if !slices.Equal(progress, expected) {
+		t.Errorf("Expected the streamed output to be logged as %v, got %v", expected, progress)
+	}
+}
+
+// signalWriter closes written on the first write, e.g. to wait for the first log line
+type signalWriter struct {
+	once    sync.Once
+	written chan struct{}
+}
+
+func (w *signalWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.written) })
+	return len(p), nil
+}
+
+func TestPiholeClient_UpdateGravityFreesRequestSlot(t *testing.T) {
+	release := make(chan struct{})
+	server := newGravityServer(t, release)
+
+	// A single request slot, which the gravity update must not hold while Pi-hole streams its output
+	client := newTestClient(t, server.URL)
+
+	progress := &signalWriter{written: make(chan struct{})}
+	done := make(chan error, 1)
+	go func() {
+		done <- client.UpdateGravity(tflogtest.RootLogger(context.Background(), progress))
+	}()
+
+	select {
+	case <-progress.written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the gravity update to start")
+	}
+
+	records := make(chan error, 1)
+	go func() {
+		_, err := client.GetDNSRecords()
+		records <- err
+	}()
+
+	select {
+	case err := <-records:
+		if err != nil {
+			t.Errorf("GetDNSRecords failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected GetDNSRecords to complete while the gravity update runs")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Expected gravity update to succeed, got: %v", err)
+	}
+}
+
+func TestPiholeClient_UpdateGravityTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := newGravityServer(t, release)
+
+	client := newTestClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.UpdateGravity(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline exceeded error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the update to be abandoned after the timeout, took %s", elapsed)
+	}
+}
+
+func TestGravityResource_CreateTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := newGravityServer(t, release)
+
+	r := NewGravityResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"timeout_seconds": tftypes.NewValue(tftypes.Number, 1),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error when the gravity update doesn't complete in time")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Gravity Update Timed Out" {
+		t.Errorf("Expected 'Gravity Update Timed Out', got '%s'", summary)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "within 1 seconds") {
+		t.Errorf("Expected the detail to name the timeout, got: %s", detail)
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HostInfoDataSource{}
+
+func NewHostInfoDataSource() datasource.DataSource {
+	return &HostInfoDataSource{}
+}
+
+type HostInfoDataSource struct {
+	client *PiholeClient
+}
+
+type HostInfoDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Hostname    types.String `tfsdk:"hostname"`
+	Kernel      types.String `tfsdk:"kernel"`
+	Model       types.String `tfsdk:"model"`
+	CPU         types.Int64  `tfsdk:"cpu"`
+	MemoryTotal types.Int64  `tfsdk:"memory_total"`
+	Uptime      types.Int64  `tfsdk:"uptime"`
+	IsDocker    types.Bool   `tfsdk:"is_docker"`
+}
+
+func (d *HostInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_info"
+}
+
+func (d *HostInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves information about the machine Pi-hole runs on, e.g. for an inventory or to check " +
+			"preconditions such as the available memory",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "Hostname of the machine",
+				Computed:            true,
+			},
+			"kernel": schema.StringAttribute{
+				MarkdownDescription: "Release of the running kernel, e.g. `6.1.21-v8+`",
+				Computed:            true,
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Hardware model, e.g. `Raspberry Pi 4 Model B Rev 1.4`. Empty if the platform doesn't report one",
+				Computed:            true,
+			},
+			"cpu": schema.Int64Attribute{
+				MarkdownDescription: "Number of CPU cores available to Pi-hole",
+				Computed:            true,
+			},
+			"memory_total": schema.Int64Attribute{
+				MarkdownDescription: "Total RAM in KiB",
+				Computed:            true,
+			},
+			"uptime": schema.Int64Attribute{
+				MarkdownDescription: "Time since the machine booted in seconds",
+				Computed:            true,
+			},
+			"is_docker": schema.BoolAttribute{
+				MarkdownDescription: "Whether Pi-hole runs in its official Docker image. Configuration that isn't kept " +
+					"in a volume is lost when such a container is recreated",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *HostInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostInfoDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := d.client.GetHostInfo()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read host info: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("host_info")
+	data.Hostname = types.StringValue(info.Hostname)
+	data.Kernel = types.StringValue(info.Kernel)
+	data.Model = types.StringValue(info.Model)
+	data.CPU = types.Int64Value(info.CPUCores)
+	data.MemoryTotal = types.Int64Value(info.MemoryTotal)
+	data.Uptime = types.Int64Value(info.Uptime)
+	data.IsDocker = types.BoolValue(info.Docker)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
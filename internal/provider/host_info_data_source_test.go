@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// newHostInfoServer wraps the fake Pi-hole with the host, system and version info endpoints answering with the given payloads
+func newHostInfoServer(t *testing.T, hostPayload, systemPayload, versionPayload string) *httptest.Server {
+	t.Helper()
+
+	fake := createMockPiholeServer()
+	t.Cleanup(fake.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload string
+		switch r.URL.Path {
+		case "/api/info/host":
+			payload = hostPayload
+		case "/api/info/system":
+			payload = systemPayload
+		case "/api/info/version":
+			payload = versionPayload
+		default:
+			fake.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHostInfoDataSource_Metadata(t *testing.T) {
+	d := NewHostInfoDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_host_info" {
+		t.Errorf("Expected TypeName to be 'pihole_host_info', got '%s'", resp.TypeName)
+	}
+}
+
+func TestHostInfoDataSource_Read(t *testing.T) {
+	testCases := []struct {
+		name           string
+		hostPayload    string
+		systemPayload  string
+		versionPayload string
+		expected       HostInfo
+	}{
+		{
+			name: "raspberry pi",
+			hostPayload: `{
+				"host": {
+					"uname": {"domainname": "(none)", "machine": "aarch64", "nodename": "pihole", "release": "6.1.21-v8+", "sysname": "Linux"},
+					"model": "Raspberry Pi 4 Model B Rev 1.4",
+					"dmi": {"product": {"name": null}}
+				},
+				"took": 0.0001
+			}`,
+			systemPayload: `{
+				"system": {
+					"uptime": 86400,
+					"memory": {"ram": {"total": 3884024, "free": 1234, "%used": 21.5}, "swap": {"total": 102396}},
+					"procs": 153,
+					"cpu": {"nprocs": 4, "%cpu": 1.2}
+				},
+				"took": 0.0002
+			}`,
+			versionPayload: `{
+				"version": {
+					"core": {"local": {"branch": "master", "version": "v6.0.4"}},
+					"ftl": {"local": {"branch": "master", "version": "v6.0.2"}},
+					"docker": {"local": null, "remote": "2025.02.1"}
+				}
+			}`,
+			expected: HostInfo{Hostname: "pihole", Kernel: "6.1.21-v8+", Model: "Raspberry Pi 4 Model B Rev 1.4", CPUCores: 4, MemoryTotal: 3884024, Uptime: 86400},
+		},
+		{
+			name:           "virtual machine with missing fields",
+			hostPayload:    `{"host": {"uname": {"nodename": "dns", "release": "6.8.0"}, "dmi": {"product": {"name": "Standard PC (Q35 + ICH9, 2009)"}}}}`,
+			systemPayload:  `{"system": {"uptime": 42}}`,
+			versionPayload: `{"version": {}}`,
+			expected:       HostInfo{Hostname: "dns", Kernel: "6.8.0", Model: "Standard PC (Q35 + ICH9, 2009)", Uptime: 42},
+		},
+		{
+			name:           "docker container",
+			hostPayload:    `{"host": {"uname": {"nodename": "4f2c1b9e0a7d", "release": "6.8.0"}}}`,
+			systemPayload:  `{"system": {"uptime": 7}}`,
+			versionPayload: `{"version": {"core": {"local": {"version": "v6.0.4"}}, "docker": {"local": "2025.02.1", "remote": "2025.02.1"}}}`,
+			expected:       HostInfo{Hostname: "4f2c1b9e0a7d", Kernel: "6.8.0", Uptime: 7, Docker: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newHostInfoServer(t, tc.hostPayload, tc.systemPayload, tc.versionPayload)
+
+			d := NewHostInfoDataSource()
+			d.(*HostInfoDataSource).client = newTestClient(t, server.URL)
+
+			resp := testDataSourceRead(t, d, nil)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			var data HostInfoDataSourceModel
+			resp.State.Get(context.Background(), &data)
+
+			actual := HostInfo{
+				Hostname:    data.Hostname.ValueString(),
+				Kernel:      data.Kernel.ValueString(),
+				Model:       data.Model.ValueString(),
+				CPUCores:    data.CPU.ValueInt64(),
+				MemoryTotal: data.MemoryTotal.ValueInt64(),
+				Uptime:      data.Uptime.ValueInt64(),
+				Docker:      data.IsDocker.ValueBool(),
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
+			}
+			if data.ID.ValueString() != "host_info" {
+				t.Errorf("Expected id 'host_info', got '%s'", data.ID.ValueString())
+			}
+		})
+	}
+}
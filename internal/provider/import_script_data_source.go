@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ImportScriptDataSource{}
+
+// invalidIdentifierChars matches the characters Terraform doesn't allow in resource names
+var invalidIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+func NewImportScriptDataSource() datasource.DataSource {
+	return &ImportScriptDataSource{}
+}
+
+type ImportScriptDataSource struct {
+	client *PiholeClient
+}
+
+type ImportScriptDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ResourcePrefix types.String `tfsdk:"resource_prefix"`
+	Content        types.String `tfsdk:"content"`
+}
+
+func (d *ImportScriptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_script"
+}
+
+func (d *ImportScriptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders Terraform `import` blocks (Terraform 1.5+) for all local DNS A and CNAME records, " +
+			"so existing records can be adopted by `pihole_dns_record` and `pihole_cname_record` resources",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"resource_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix of the generated resource names, e.g. `legacy_` (default: none)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`),
+						"must start with a letter or underscore and contain only letters, digits, underscores and dashes"),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The rendered import blocks",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImportScriptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImportScriptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImportScriptDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := d.client.Snapshot(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read DNS and CNAME records: "+err.Error())
+		return
+	}
+	records, cnameRecords := snapshot.DNSRecords, snapshot.CNAMERecords
+
+	data.ID = types.StringValue("import_script")
+	data.Content = types.StringValue(renderImportBlocks(data.ResourcePrefix.ValueString(), records, cnameRecords))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// renderImportBlocks renders an import block per domain with DNS records and per CNAME record, sorted by
+// domain. Both resources are imported by their domain, so a domain with several IPs gets a single block.
+func renderImportBlocks(prefix string, records []DNSRecord, cnameRecords []CNAMERecord) string {
+	var b strings.Builder
+
+	render := func(resourceType string, domains []string) {
+		sort.Strings(domains)
+
+		seen := make(map[string]bool, len(domains))
+		names := make(map[string]bool, len(domains))
+		for _, domain := range domains {
+			if seen[domain] {
+				continue
+			}
+			seen[domain] = true
+
+			name := importResourceName(prefix, domain)
+			// Domains that only differ in characters a resource name can't hold get numbered names
+			for i := 2; names[name]; i++ {
+				name = fmt.Sprintf("%s_%d", importResourceName(prefix, domain), i)
+			}
+			names[name] = true
+
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "import {\n  to = %s.%s\n  id = %q\n}\n", resourceType, name, domain)
+		}
+	}
+
+	dnsDomains := make([]string, 0, len(records))
+	for _, record := range records {
+		dnsDomains = append(dnsDomains, record.Domain)
+	}
+	render("pihole_dns_record", dnsDomains)
+
+	cnameDomains := make([]string, 0, len(cnameRecords))
+	for _, record := range cnameRecords {
+		cnameDomains = append(cnameDomains, record.Domain)
+	}
+	render("pihole_cname_record", cnameDomains)
+
+	return b.String()
+}
+
+// importResourceName derives a Terraform resource name from the prefix and the domain, e.g. nas_homelab_local
+func importResourceName(prefix, domain string) string {
+	name := prefix + invalidIdentifierChars.ReplaceAllString(strings.ToLower(domain), "_")
+	// Resource names must not start with a digit or a dash
+	if name == "" || !(name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z') || (name[0] >= 'A' && name[0] <= 'Z')) {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportScriptDataSource_Metadata(t *testing.T) {
+	d := NewImportScriptDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_import_script" {
+		t.Errorf("Expected TypeName to be 'pihole_import_script', got '%s'", resp.TypeName)
+	}
+}
+
+func TestRenderImportBlocks(t *testing.T) {
+	records := []DNSRecord{
+		{Domain: "nas.homelab.local", IP: "192.168.1.20"},
+		{Domain: "nas.homelab.local", IP: "fd00::20"},
+		{Domain: "1password.homelab.local", IP: "192.168.1.30"},
+	}
+	cnameRecords := []CNAMERecord{
+		{Domain: "www.homelab.local", Target: "nas.homelab.local"},
+	}
+
+	expected := strings.Join([]string{
+		"import {",
+		"  to = pihole_dns_record.legacy_1password_homelab_local",
+		`  id = "1password.homelab.local"`,
+		"}",
+		"",
+		"import {",
+		"  to = pihole_dns_record.legacy_nas_homelab_local",
+		`  id = "nas.homelab.local"`,
+		"}",
+		"",
+		"import {",
+		"  to = pihole_cname_record.legacy_www_homelab_local",
+		`  id = "www.homelab.local"`,
+		"}",
+		"",
+	}, "\n")
+
+	content := renderImportBlocks("legacy_", records, cnameRecords)
+	if content != expected {
+		t.Errorf("Unexpected import blocks:\n%s\nexpected:\n%s", content, expected)
+	}
+
+	// The blocks must parse as HCL with valid resource addresses
+	file, diags := hclsyntax.ParseConfig([]byte(renderImportBlocks("", records, cnameRecords)), "imports.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Expected the import blocks to parse, got %v", diags)
+	}
+	blocks := file.Body.(*hclsyntax.Body).Blocks
+	if len(blocks) != 3 {
+		t.Fatalf("Expected 3 import blocks, got %d", len(blocks))
+	}
+	for _, block := range blocks {
+		if block.Type != "import" {
+			t.Errorf("Expected an import block, got '%s'", block.Type)
+		}
+		to, ok := block.Body.Attributes["to"]
+		if !ok {
+			t.Fatalf("Expected import block to have 'to'")
+		}
+		traversal, diags := hcl.AbsTraversalForExpr(to.Expr)
+		if diags.HasErrors() || len(traversal) != 2 {
+			t.Errorf("Expected 'to' to be a resource address, got %v", diags)
+		}
+	}
+}
+
+func TestImportResourceName(t *testing.T) {
+	testCases := []struct {
+		prefix, domain, expected string
+	}{
+		{"", "nas.homelab.local", "nas_homelab_local"},
+		{"", "NAS.Homelab.Local", "nas_homelab_local"},
+		{"", "1password.local", "_1password_local"},
+		{"", "-dash.local", "_-dash_local"},
+		{"pihole_", "my-host.lan", "pihole_my-host_lan"},
+	}
+
+	for _, tc := range testCases {
+		if name := importResourceName(tc.prefix, tc.domain); name != tc.expected {
+			t.Errorf("importResourceName(%q, %q): expected '%s', got '%s'", tc.prefix, tc.domain, tc.expected, name)
+		}
+	}
+}
+
+func TestImportScriptDataSource_Read(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	d := NewImportScriptDataSource()
+	d.(*ImportScriptDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, map[string]tftypes.Value{
+		"resource_prefix": tftypes.NewValue(tftypes.String, "adopted_"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var content types.String
+	resp.State.GetAttribute(context.Background(), path.Root("content"), &content)
+	for _, address := range []string{
+		"pihole_dns_record.adopted_test_example_com",
+		"pihole_dns_record.adopted_server_example_com",
+		"pihole_cname_record.adopted_www_example_com",
+		"pihole_cname_record.adopted_mail_example_com",
+	} {
+		if !strings.Contains(content.ValueString(), "to = "+address+"\n") {
+			t.Errorf("Expected an import block for %s, got:\n%s", address, content.ValueString())
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &InventoryDataSource{}
+
+func NewInventoryDataSource() datasource.DataSource {
+	return &InventoryDataSource{}
+}
+
+type InventoryDataSource struct {
+	client *PiholeClient
+}
+
+type InventoryDataSourceModel struct {
+	ID           types.String                 `tfsdk:"id"`
+	DNSRecords   []DNSRecordDataSourceModel   `tfsdk:"dns_records"`
+	CNAMERecords []CNAMERecordDataSourceModel `tfsdk:"cname_records"`
+	JSON         types.String                 `tfsdk:"json"`
+}
+
+// inventoryDocument is the layout of the json attribute
+type inventoryDocument struct {
+	DNSRecords   []DNSRecord   `json:"dns_records"`
+	CNAMERecords []CNAMERecord `json:"cname_records"`
+}
+
+func (d *InventoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inventory"
+}
+
+func (d *InventoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports all local DNS A and CNAME records of Pi-hole, both as lists and as a single JSON " +
+			"document, e.g. for external tooling, diffing or generating documentation",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"dns_records": schema.ListNestedAttribute{
+				MarkdownDescription: "DNS A records, sorted by domain",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "The IP address",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"cname_records": schema.ListNestedAttribute{
+				MarkdownDescription: "CNAME records, sorted by domain",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The CNAME domain name",
+							Computed:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "The target domain name",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The records as a JSON document of the form " +
+					"`{\"dns_records\": [{\"domain\", \"ip\"}], \"cname_records\": [{\"domain\", \"target\", \"ttl\"}]}`. " +
+					"`ttl` is only present for CNAME records that have one",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *InventoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *InventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InventoryDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := d.client.Snapshot(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read local DNS records: "+err.Error())
+		return
+	}
+
+	// Sort copies so the export is stable between runs and the shared snapshot stays untouched
+	document := inventoryDocument{
+		DNSRecords:   append([]DNSRecord{}, snapshot.DNSRecords...),
+		CNAMERecords: append([]CNAMERecord{}, snapshot.CNAMERecords...),
+	}
+	sort.SliceStable(document.DNSRecords, func(i, j int) bool {
+		return document.DNSRecords[i].Domain < document.DNSRecords[j].Domain
+	})
+	sort.SliceStable(document.CNAMERecords, func(i, j int) bool {
+		return document.CNAMERecords[i].Domain < document.CNAMERecords[j].Domain
+	})
+
+	content, err := json.Marshal(document)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to encode inventory: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("inventory")
+	data.DNSRecords = make([]DNSRecordDataSourceModel, 0, len(document.DNSRecords))
+	for _, record := range document.DNSRecords {
+		data.DNSRecords = append(data.DNSRecords, DNSRecordDataSourceModel{
+			Domain: types.StringValue(record.Domain),
+			IP:     types.StringValue(record.IP),
+		})
+	}
+	data.CNAMERecords = make([]CNAMERecordDataSourceModel, 0, len(document.CNAMERecords))
+	for _, record := range document.CNAMERecords {
+		data.CNAMERecords = append(data.CNAMERecords, CNAMERecordDataSourceModel{
+			Domain: types.StringValue(record.Domain),
+			Target: types.StringValue(record.Target),
+		})
+	}
+	data.JSON = types.StringValue(string(content))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
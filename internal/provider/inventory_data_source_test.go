@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestInventoryDataSource_Metadata(t *testing.T) {
+	d := NewInventoryDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_inventory" {
+		t.Errorf("Expected TypeName to be 'pihole_inventory', got '%s'", resp.TypeName)
+	}
+}
+
+func TestInventoryDataSource_Read(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {
+			"hosts": []string{
+				"192.168.1.101 server.example.com",
+				"192.168.1.20 nas.example.com",
+			},
+			"cnameRecords": []string{
+				"www.example.com,server.example.com,300",
+				"files.example.com,nas.example.com",
+			},
+		},
+	})
+	defer server.Close()
+
+	d := NewInventoryDataSource()
+	d.(*InventoryDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data InventoryDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	// Records are sorted by domain, independent of their order in Pi-hole
+	if len(data.DNSRecords) != 2 || data.DNSRecords[0].Domain.ValueString() != "nas.example.com" {
+		t.Errorf("Expected 2 DNS records starting with nas.example.com, got %v", data.DNSRecords)
+	}
+	if len(data.CNAMERecords) != 2 || data.CNAMERecords[0].Domain.ValueString() != "files.example.com" {
+		t.Errorf("Expected 2 CNAME records starting with files.example.com, got %v", data.CNAMERecords)
+	}
+
+	expected := `{"dns_records":[{"domain":"nas.example.com","ip":"192.168.1.20"},{"domain":"server.example.com","ip":"192.168.1.101"}],` +
+		`"cname_records":[{"domain":"files.example.com","target":"nas.example.com"},{"domain":"www.example.com","target":"server.example.com","ttl":300}]}`
+	if data.JSON.ValueString() != expected {
+		t.Errorf("Unexpected json:\n got: %s\nwant: %s", data.JSON.ValueString(), expected)
+	}
+}
+
+func TestInventoryDataSource_ReadEmpty(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"hosts": []string{}, "cnameRecords": []string{}},
+	})
+	defer server.Close()
+
+	d := NewInventoryDataSource()
+	d.(*InventoryDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data InventoryDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	// Tools consuming the export get empty arrays rather than null
+	var document map[string][]interface{}
+	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &document); err != nil {
+		t.Fatalf("Expected valid json, got %s: %v", data.JSON.ValueString(), err)
+	}
+	if document["dns_records"] == nil || document["cname_records"] == nil {
+		t.Errorf("Expected empty arrays for both record types, got %s", data.JSON.ValueString())
+	}
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ListeningConfigResource{}
+var _ resource.ResourceWithValidateConfig = &ListeningConfigResource{}
+
+// listeningModes are the FTL listening modes that can be managed by this resource
+var listeningModes = []string{"LOCAL", "SINGLE", "BIND", "ALL"}
+
+func NewListeningConfigResource() resource.Resource {
+	return &ListeningConfigResource{}
+}
+
+type ListeningConfigResource struct {
+	client *PiholeClient
+}
+
+type ListeningConfigResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ListeningMode types.String `tfsdk:"listening_mode"`
+	Interface     types.String `tfsdk:"interface"`
+}
+
+func (r *ListeningConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_listening_config"
+}
+
+func (r *ListeningConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the interfaces Pi-hole FTL listens on for DNS queries (`dns.listeningMode` and `dns.interface`). " +
+			"**Warning**: This is a safety-sensitive setting. A wrong listening mode or interface can stop Pi-hole from " +
+			"answering DNS queries for your whole network. Deleting this resource resets Pi-hole to the `LOCAL` listening mode.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Listening configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"listening_mode": schema.StringAttribute{
+				MarkdownDescription: "Listening mode of FTL. One of `LOCAL` (only devices up to one hop away), " +
+					"`SINGLE` (all origins on the configured interface), `BIND` (bind only to the configured interface) " +
+					"or `ALL` (all origins on all interfaces).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(listeningModes...),
+				},
+			},
+			"interface": schema.StringAttribute{
+				MarkdownDescription: "Interface to listen on (e.g. `eth0`). Required for the `SINGLE` and `BIND` listening modes.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ListeningConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ListeningConfigResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ListeningMode.IsUnknown() || data.Interface.IsUnknown() {
+		return
+	}
+
+	mode := data.ListeningMode.ValueString()
+	if (mode == "SINGLE" || mode == "BIND") && data.Interface.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("interface"),
+			"Missing Listening Interface",
+			fmt.Sprintf("The '%s' listening mode requires an interface to be set.", mode),
+		)
+	}
+}
+
+func (r *ListeningConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ListeningConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ListeningConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", listeningConfigValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set listening configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read listening configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListeningConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ListeningConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read listening configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListeningConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ListeningConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", listeningConfigValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update listening configuration, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read listening configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListeningConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.client.SetConfigSection("dns", map[string]interface{}{
+		"listeningMode": "LOCAL",
+		"interface":     "",
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset listening configuration, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the listening configuration currently active in Pi-hole
+func (r *ListeningConfigResource) readInto(data *ListeningConfigResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	mode, ok := dnsConfig["listeningMode"].(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.listeningMode: %v", dnsConfig["listeningMode"])
+	}
+	iface, _ := dnsConfig["interface"].(string)
+
+	data.ID = types.StringValue("listening_config")
+	// FTL reports the mode in upper case, but older versions don't, so the comparison ignores the case
+	data.ListeningMode = types.StringValue(strings.ToUpper(mode))
+	// Keep the interface as configured if FTL reports it only with surrounding whitespace changed
+	if data.Interface.IsNull() || data.Interface.IsUnknown() || !sameListeningInterface(data.Interface.ValueString(), iface) {
+		data.Interface = types.StringValue(iface)
+	}
+
+	return nil
+}
+
+// sameListeningInterface reports whether two interface settings name the same interface. Interface names never
+// contain whitespace, so whitespace around them is not significant.
+func sameListeningInterface(configured, reported string) bool {
+	return strings.TrimSpace(configured) == strings.TrimSpace(reported)
+}
+
+// listeningConfigValues builds the dns section payload from the planned model
+func listeningConfigValues(data ListeningConfigResourceModel) map[string]interface{} {
+	values := map[string]interface{}{
+		"listeningMode": data.ListeningMode.ValueString(),
+	}
+	if !data.Interface.IsNull() && !data.Interface.IsUnknown() {
+		values["interface"] = data.Interface.ValueString()
+	}
+	return values
+}
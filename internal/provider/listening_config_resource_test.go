@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestListeningConfigResource_Schema(t *testing.T) {
+	r := NewListeningConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	modeAttr, exists := schemaResp.Schema.Attributes["listening_mode"]
+	if !exists {
+		t.Error("Schema should have 'listening_mode' attribute")
+	} else if !modeAttr.IsRequired() {
+		t.Error("'listening_mode' attribute should be required")
+	}
+
+	ifaceAttr, exists := schemaResp.Schema.Attributes["interface"]
+	if !exists {
+		t.Error("Schema should have 'interface' attribute")
+	} else if !ifaceAttr.IsOptional() {
+		t.Error("'interface' attribute should be optional")
+	}
+}
+
+func TestListeningConfigResource_Metadata(t *testing.T) {
+	r := NewListeningConfigResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_listening_config" {
+		t.Errorf("Expected TypeName to be 'pihole_listening_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestListeningConfigResource_ModeValidation(t *testing.T) {
+	r := NewListeningConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	modeAttr := schemaResp.Schema.Attributes["listening_mode"].(schema.StringAttribute)
+
+	testCases := []struct {
+		mode      string
+		expectErr bool
+	}{
+		{"LOCAL", false},
+		{"SINGLE", false},
+		{"BIND", false},
+		{"ALL", false},
+		{"NONE", true},
+		{"local", true},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("listening_mode"),
+				ConfigValue: types.StringValue(tc.mode),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range modeAttr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For mode '%s': expected error %v, got %v", tc.mode, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestListeningConfigResource_RequiresInterface(t *testing.T) {
+	r := NewListeningConfigResource()
+
+	resp := testResourceValidateConfig(t, r, map[string]tftypes.Value{
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected SINGLE listening mode without an interface to be rejected")
+	}
+
+	resp = testResourceValidateConfig(t, r, map[string]tftypes.Value{
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+		"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Expected SINGLE listening mode with an interface to be valid, got: %v", resp.Diagnostics.Errors())
+	}
+
+	resp = testResourceValidateConfig(t, r, map[string]tftypes.Value{
+		"listening_mode": tftypes.NewValue(tftypes.String, "LOCAL"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Expected LOCAL listening mode without an interface to be valid, got: %v", resp.Diagnostics.Errors())
+	}
+}
+
+func TestListeningConfigResource_SetSingleWithInterface(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "LOCAL", "interface": "", "port": 53},
+	})
+	defer server.Close()
+
+	r := NewListeningConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+		"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	if dnsConfig["listeningMode"] != "SINGLE" {
+		t.Errorf("Expected listeningMode 'SINGLE', got %v", dnsConfig["listeningMode"])
+	}
+	if dnsConfig["interface"] != "eth0" {
+		t.Errorf("Expected interface 'eth0', got %v", dnsConfig["interface"])
+	}
+	// Unrelated dns settings must be left untouched by the PATCH
+	if dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns.port to be preserved, got %v", dnsConfig["port"])
+	}
+
+	var mode, iface types.String
+	resp.State.GetAttribute(context.Background(), path.Root("listening_mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("interface"), &iface)
+	if mode.ValueString() != "SINGLE" || iface.ValueString() != "eth0" {
+		t.Errorf("Expected state SINGLE/eth0, got %s/%s", mode.ValueString(), iface.ValueString())
+	}
+}
+
+func TestListeningConfigResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "ALL", "interface": "eth1"},
+	})
+	defer server.Close()
+
+	r := NewListeningConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "listening_config"),
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+		"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var mode, iface types.String
+	resp.State.GetAttribute(context.Background(), path.Root("listening_mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("interface"), &iface)
+	if mode.ValueString() != "ALL" || iface.ValueString() != "eth1" {
+		t.Errorf("Expected state to reflect Pi-hole (ALL/eth1), got %s/%s", mode.ValueString(), iface.ValueString())
+	}
+}
+
+func TestListeningConfigResource_DeleteResetsToLocal(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "SINGLE", "interface": "eth0"},
+	})
+	defer server.Close()
+
+	r := NewListeningConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "listening_config"),
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+		"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	if dnsConfig["listeningMode"] != "LOCAL" || dnsConfig["interface"] != "" {
+		t.Errorf("Expected listening config to be reset to LOCAL, got %v/%v", dnsConfig["listeningMode"], dnsConfig["interface"])
+	}
+}
+
+func TestListeningConfigResource_ReadIgnoresCosmeticDifferences(t *testing.T) {
+	// FTL reports the interface with a trailing newline and the mode in lower case
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns": {"listeningMode": "single", "interface": "eth0\n"},
+	})
+	defer server.Close()
+
+	r := NewListeningConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "listening_config"),
+		"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+		"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var mode, iface types.String
+	resp.State.GetAttribute(context.Background(), path.Root("listening_mode"), &mode)
+	resp.State.GetAttribute(context.Background(), path.Root("interface"), &iface)
+	if mode.ValueString() != "SINGLE" || iface.ValueString() != "eth0" {
+		t.Errorf("Expected no drift from the configured SINGLE/eth0, got %q/%q", mode.ValueString(), iface.ValueString())
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NetworkGatewayDataSource{}
+
+func NewNetworkGatewayDataSource() datasource.DataSource {
+	return &NetworkGatewayDataSource{}
+}
+
+type NetworkGatewayDataSource struct {
+	client *PiholeClient
+}
+
+type NetworkGatewayDataSourceModel struct {
+	ID        types.String             `tfsdk:"id"`
+	Interface types.String             `tfsdk:"interface"`
+	GatewayIP types.String             `tfsdk:"gateway_ip"`
+	Gateways  []GatewayDataSourceModel `tfsdk:"gateways"`
+}
+
+type GatewayDataSourceModel struct {
+	Family    types.String `tfsdk:"family"`
+	Interface types.String `tfsdk:"interface"`
+	Address   types.String `tfsdk:"address"`
+	Local     types.List   `tfsdk:"local"`
+}
+
+func (d *NetworkGatewayDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_gateway"
+}
+
+func (d *NetworkGatewayDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the default gateway Pi-hole detected on its network, e.g. the router IP for conditional forwarding",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"interface": schema.StringAttribute{
+				MarkdownDescription: "Interface of the IPv4 default gateway, empty if there is none",
+				Computed:            true,
+			},
+			"gateway_ip": schema.StringAttribute{
+				MarkdownDescription: "Address of the IPv4 default gateway, empty if there is none",
+				Computed:            true,
+			},
+			"gateways": schema.ListNestedAttribute{
+				MarkdownDescription: "Default gateways of all address families",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"family": schema.StringAttribute{
+							MarkdownDescription: "Address family, `inet` (IPv4) or `inet6` (IPv6)",
+							Computed:            true,
+						},
+						"interface": schema.StringAttribute{
+							MarkdownDescription: "Interface the gateway is reached through",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Address of the gateway",
+							Computed:            true,
+						},
+						"local": schema.ListAttribute{
+							MarkdownDescription: "Pi-hole's own addresses on the interface",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NetworkGatewayDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NetworkGatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworkGatewayDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateways, err := d.client.GetNetworkGateways()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read network gateway: "+err.Error())
+		return
+	}
+
+	data.Interface = types.StringValue("")
+	data.GatewayIP = types.StringValue("")
+
+	gatewayModels := make([]GatewayDataSourceModel, 0, len(gateways))
+	for _, gateway := range gateways {
+		local, diags := types.ListValueFrom(ctx, types.StringType, gateway.Local)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		gatewayModels = append(gatewayModels, GatewayDataSourceModel{
+			Family:    types.StringValue(gateway.Family),
+			Interface: types.StringValue(gateway.Interface),
+			Address:   types.StringValue(gateway.Address),
+			Local:     local,
+		})
+
+		// The first IPv4 gateway is the one clients on the LAN use as their router
+		if gateway.Family == "inet" && data.GatewayIP.ValueString() == "" {
+			data.Interface = types.StringValue(gateway.Interface)
+			data.GatewayIP = types.StringValue(gateway.Address)
+		}
+	}
+
+	data.ID = types.StringValue("network_gateway")
+	data.Gateways = gatewayModels
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
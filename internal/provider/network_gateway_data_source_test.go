@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// newNetworkGatewayServer wraps the fake Pi-hole with a gateway endpoint answering with the given payload
+func newNetworkGatewayServer(t *testing.T, payload string) *httptest.Server {
+	t.Helper()
+
+	fake := createMockPiholeServer()
+	t.Cleanup(fake.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/network/gateway" {
+			fake.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNetworkGatewayDataSource_Metadata(t *testing.T) {
+	d := NewNetworkGatewayDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_network_gateway" {
+		t.Errorf("Expected TypeName to be 'pihole_network_gateway', got '%s'", resp.TypeName)
+	}
+}
+
+func TestNetworkGatewayDataSource_Read(t *testing.T) {
+	server := newNetworkGatewayServer(t, `{
+		"gateway": [
+			{"family": "inet6", "interface": "eth0", "address": "fe80::1", "local": ["fe80::dea6:32ff:fe01:2345"]},
+			{"family": "inet", "interface": "eth0", "address": "192.168.1.1", "local": ["192.168.1.10"]}
+		],
+		"took": 0.0003
+	}`)
+
+	d := NewNetworkGatewayDataSource()
+	d.(*NetworkGatewayDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data NetworkGatewayDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	if data.ID.ValueString() != "network_gateway" {
+		t.Errorf("Expected id 'network_gateway', got '%s'", data.ID.ValueString())
+	}
+	// The IPv4 gateway is picked even though Pi-hole lists the IPv6 one first
+	if data.GatewayIP.ValueString() != "192.168.1.1" || data.Interface.ValueString() != "eth0" {
+		t.Errorf("Expected gateway 192.168.1.1 on eth0, got %s on %s", data.GatewayIP.ValueString(), data.Interface.ValueString())
+	}
+	if len(data.Gateways) != 2 {
+		t.Fatalf("Expected 2 gateways, got %d", len(data.Gateways))
+	}
+
+	var local []string
+	data.Gateways[1].Local.ElementsAs(context.Background(), &local, false)
+	if data.Gateways[1].Family.ValueString() != "inet" || len(local) != 1 || local[0] != "192.168.1.10" {
+		t.Errorf("Unexpected IPv4 gateway details: %+v", data.Gateways[1])
+	}
+}
+
+func TestNetworkGatewayDataSource_ReadWithoutIPv4Gateway(t *testing.T) {
+	server := newNetworkGatewayServer(t, `{"gateway": [], "took": 0.0001}`)
+
+	d := NewNetworkGatewayDataSource()
+	d.(*NetworkGatewayDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data NetworkGatewayDataSourceModel
+	resp.State.Get(context.Background(), &data)
+	if data.GatewayIP.ValueString() != "" || len(data.Gateways) != 0 {
+		t.Errorf("Expected no gateway, got '%s' and %d gateways", data.GatewayIP.ValueString(), len(data.Gateways))
+	}
+}
@@ -2,23 +2,43 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/singleflight"
 )
 
 const providerTypeName = "pihole"
 
 var _ provider.Provider = &PiholeProvider{}
 
+// minTLSVersions maps the accepted min_tls_version values to their crypto/tls constants
+var minTLSVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
 // Global client cache to reuse sessions across provider instances
 var (
 	clientCache = make(map[string]*PiholeClient)
 	cacheMutex  sync.RWMutex
+
+	// clientCreation deduplicates concurrent creation of the same client, so cacheMutex isn't held during the
+	// authentication round-trip and Pi-holes with different cache keys authenticate in parallel
+	clientCreation singleflight.Group
 )
 
 type PiholeProvider struct {
@@ -26,46 +46,95 @@ type PiholeProvider struct {
 }
 
 type PiholeProviderModel struct {
-	URL              types.String `tfsdk:"url"`
-	Password         types.String `tfsdk:"password"`
-	MaxConnections   types.Int64  `tfsdk:"max_connections"`
-	RequestDelay     types.Int64  `tfsdk:"request_delay_ms"`
-	RetryAttempts    types.Int64  `tfsdk:"retry_attempts"`
-	RetryBackoffBase types.Int64  `tfsdk:"retry_backoff_base_ms"`
-	InsecureTLS      types.Bool   `tfsdk:"insecure_tls"`
+	URL               types.String `tfsdk:"url"`
+	Password          types.String `tfsdk:"password"`
+	MaxConnections    types.Int64  `tfsdk:"max_connections"`
+	RequestDelay      types.Int64  `tfsdk:"request_delay_ms"`
+	RetryAttempts     types.Int64  `tfsdk:"retry_attempts"`
+	RetryBackoffBase  types.Int64  `tfsdk:"retry_backoff_base_ms"`
+	RetryMaxBackoff   types.Int64  `tfsdk:"retry_max_backoff_ms"`
+	ReadRetries       types.Int64  `tfsdk:"read_retry_attempts"`
+	WriteRetries      types.Int64  `tfsdk:"write_retry_attempts"`
+	InsecureTLS       types.Bool   `tfsdk:"insecure_tls"`
+	MinTLSVersion     types.String `tfsdk:"min_tls_version"`
+	DisableKeepAlives types.Bool   `tfsdk:"disable_keep_alives"`
+	DefaultGroupIDs   types.List   `tfsdk:"default_group_ids"`
+	EnforceLocalTLD   types.List   `tfsdk:"enforce_local_tld"`
+	AutoGravity       types.Bool   `tfsdk:"auto_gravity"`
+	VerifyWrites      types.Bool   `tfsdk:"verify_writes"`
 }
 
-// getOrCreateClient returns a cached client or creates a new one
-func getOrCreateClient(url, password string, config ClientConfig) (*PiholeClient, error) {
-	// Create cache key from URL and password
-	cacheKey := url + "|" + password
+// getOrCreateClient returns a cached client or creates a new one. A new client logs through the logger of ctx.
+func getOrCreateClient(ctx context.Context, url, password string, config ClientConfig) (*PiholeClient, error) {
+	cacheKey := clientCacheKey(url, password, config)
 
 	// Try to get existing client
-	cacheMutex.RLock()
-	if client, exists := clientCache[cacheKey]; exists {
-		cacheMutex.RUnlock()
+	if client, exists := cachedClient(cacheKey); exists {
 		return client, nil
 	}
-	cacheMutex.RUnlock()
 
-	// Create new client with write lock
-	cacheMutex.Lock()
-	defer cacheMutex.Unlock()
+	// Concurrent calls for the same key share one creation and its authentication
+	result, err, _ := clientCreation.Do(cacheKey, func() (interface{}, error) {
+		// Double-check pattern - a creation that just finished might have cached it
+		if client, exists := cachedClient(cacheKey); exists {
+			return client, nil
+		}
 
-	// Double-check pattern - another goroutine might have created it
-	if client, exists := clientCache[cacheKey]; exists {
-		return client, nil
-	}
+		// Create new client, authenticating without holding the cache lock
+		client, err := NewPiholeClient(url, password, config)
+		if err != nil {
+			return nil, err
+		}
+		client.logCtx = ctx
 
-	// Create new client
-	client, err := NewPiholeClient(url, password, config)
+		// Cache the client
+		cacheMutex.Lock()
+		clientCache[cacheKey] = client
+		cacheMutex.Unlock()
+
+		return client, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the client
-	clientCache[cacheKey] = client
-	return client, nil
+	return result.(*PiholeClient), nil
+}
+
+// clientCacheKey identifies a cached client by URL, password and config. The config is fixed when a client is
+// created, so provider aliases that point at the same Pi-hole with different settings each get their own client.
+func clientCacheKey(url, password string, config ClientConfig) string {
+	// The fields are encoded in declaration order, so equal configs give equal keys
+	encoded, _ := json.Marshal(config)
+	return url + "|" + string(encoded) + "|" + password
+}
+
+// cachedClient returns the cached client for the cache key, if any
+func cachedClient(cacheKey string) (*PiholeClient, bool) {
+	cacheMutex.RLock()
+	defer cacheMutex.RUnlock()
+
+	client, exists := clientCache[cacheKey]
+	return client, exists
+}
+
+// rekeyCachedClient moves a cached client from the cache key of its old password to the one of its new password.
+// Clients that aren't cached, e.g. in tests, are left alone.
+func rekeyCachedClient(client *PiholeClient, oldPassword, newPassword string) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	var oldKeys []string
+	for cacheKey, cached := range clientCache {
+		if cached == client && strings.HasSuffix(cacheKey, "|"+oldPassword) {
+			oldKeys = append(oldKeys, cacheKey)
+		}
+	}
+
+	for _, cacheKey := range oldKeys {
+		delete(clientCache, cacheKey)
+		clientCache[strings.TrimSuffix(cacheKey, "|"+oldPassword)+"|"+newPassword] = client
+	}
 }
 
 // clearClientCache clears all cached clients (useful for testing)
@@ -109,23 +178,98 @@ func (p *PiholeProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			"max_connections": schema.Int64Attribute{
 				MarkdownDescription: "Maximum number of concurrent connections to Pi-hole (default: 1)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"request_delay_ms": schema.Int64Attribute{
 				MarkdownDescription: "Delay in milliseconds between API requests (default: 300)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"retry_attempts": schema.Int64Attribute{
 				MarkdownDescription: "Number of retry attempts for failed requests (default: 3)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"read_retry_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Number of retry attempts for failed reads, which are always safe to repeat (default: `retry_attempts`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"write_retry_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Number of retry attempts for failed writes that are safe to repeat (default: `retry_attempts`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"retry_backoff_base_ms": schema.Int64Attribute{
 				MarkdownDescription: "Base delay in milliseconds for retry backoff (default: 500)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff_ms": schema.Int64Attribute{
+				MarkdownDescription: "Maximum delay in milliseconds before a single retry, `0` disables the cap (default: 5000)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"insecure_tls": schema.BoolAttribute{
 				MarkdownDescription: "Skip TLS certificate verification (default: false)",
 				Optional:            true,
 			},
+			"min_tls_version": schema.StringAttribute{
+				MarkdownDescription: "Lowest TLS version accepted when connecting to Pi-hole over HTTPS, `1.2` or `1.3` " +
+					"(default: Go's default, currently TLS 1.2)",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1.2", "1.3"),
+				},
+			},
+			"disable_keep_alives": schema.BoolAttribute{
+				MarkdownDescription: "Open a new connection for every request instead of reusing idle ones. " +
+					"Works around proxies that silently drop keep-alive connections (default: false)",
+				Optional: true,
+			},
+			"default_group_ids": schema.ListAttribute{
+				MarkdownDescription: "Group IDs assigned to new domains when a resource does not set `groups` " +
+					"(default: Pi-hole's Default group, ID 0)",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+			},
+			"enforce_local_tld": schema.ListAttribute{
+				MarkdownDescription: "Suffixes the domains of new DNS and CNAME records must end in, e.g. `internal` or " +
+					"`home.arpa`. Records outside them fail at plan time, which guards against typos creating records " +
+					"for public domains (default: all domains are allowed)",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"auto_gravity": schema.BoolAttribute{
+				MarkdownDescription: "Update gravity automatically after `pihole_domain`, `pihole_domains_from_file`, `pihole_adlists` and `pihole_adlists_toggle` changes. " +
+					"Changes applied in parallel share a single update (default: false)",
+				Optional: true,
+			},
+			"verify_writes": schema.BoolAttribute{
+				MarkdownDescription: "Read DNS and CNAME records back after creating them and fail if Pi-hole didn't store " +
+					"them. Costs an extra request per record (default: false)",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -141,11 +285,12 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	// Set defaults for optional parameters
 	config := ClientConfig{
-		MaxConnections: 1,
-		RequestDelayMs: 300,
-		RetryAttempts:  3,
-		RetryBackoffMs: 500,
-		InsecureTLS:    false, // Default to secure TLS verification
+		MaxConnections:    1,
+		RequestDelayMs:    300,
+		RetryAttempts:     3,
+		RetryBackoffMs:    500,
+		RetryMaxBackoffMs: 5000,
+		InsecureTLS:       false, // Default to secure TLS verification
 	}
 
 	// Override defaults with user-provided values
@@ -158,21 +303,59 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	if !data.RetryAttempts.IsNull() {
 		config.RetryAttempts = int(data.RetryAttempts.ValueInt64())
 	}
+	if !data.ReadRetries.IsNull() {
+		readRetries := int(data.ReadRetries.ValueInt64())
+		config.ReadRetryAttempts = &readRetries
+	}
+	if !data.WriteRetries.IsNull() {
+		writeRetries := int(data.WriteRetries.ValueInt64())
+		config.WriteRetryAttempts = &writeRetries
+	}
 	if !data.RetryBackoffBase.IsNull() {
 		config.RetryBackoffMs = int(data.RetryBackoffBase.ValueInt64())
 	}
+	if !data.RetryMaxBackoff.IsNull() {
+		config.RetryMaxBackoffMs = int(data.RetryMaxBackoff.ValueInt64())
+	}
 	if !data.InsecureTLS.IsNull() {
 		config.InsecureTLS = data.InsecureTLS.ValueBool()
 	}
+	if config.InsecureTLS {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_tls"),
+			"TLS Certificate Verification Disabled",
+			"insecure_tls is enabled, so the provider accepts any certificate Pi-hole presents, including one of an attacker "+
+				"intercepting the connection and the admin password sent with it. Prefer giving Pi-hole a certificate "+
+				"trusted by this machine and leave insecure_tls unset.",
+		)
+	}
+	if !data.MinTLSVersion.IsNull() {
+		config.MinTLSVersion = minTLSVersions[data.MinTLSVersion.ValueString()]
+	}
+	if !data.DisableKeepAlives.IsNull() {
+		config.DisableKeepAlives = data.DisableKeepAlives.ValueBool()
+	}
+	if !data.DefaultGroupIDs.IsNull() {
+		resp.Diagnostics.Append(data.DefaultGroupIDs.ElementsAs(ctx, &config.DefaultGroupIDs, false)...)
+	}
+	if !data.AutoGravity.IsNull() {
+		config.AutoGravity = data.AutoGravity.ValueBool()
+	}
+	if !data.EnforceLocalTLD.IsNull() {
+		resp.Diagnostics.Append(data.EnforceLocalTLD.ElementsAs(ctx, &config.LocalSuffixes, false)...)
+	}
+	if !data.VerifyWrites.IsNull() {
+		config.VerifyWrites = data.VerifyWrites.ValueBool()
+	}
+
+	// Report every attribute that couldn't be read before giving up, so all of them can be fixed at once
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	client, err := getOrCreateClient(data.URL.ValueString(), data.Password.ValueString(), config)
+	client, err := getOrCreateClient(ctx, data.URL.ValueString(), data.Password.ValueString(), config)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Pi-hole API Client",
-			"An unexpected error occurred when creating the Pi-hole API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Pi-hole Client Error: "+err.Error(),
-		)
+		resp.Diagnostics.AddError(clientErrorDiagnostic(err))
 		return
 	}
 
@@ -185,6 +368,26 @@ func (p *PiholeProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewDNSRecordResource,
 		NewCNAMERecordResource,
 		NewConfigResource,
+		NewListeningConfigResource,
+		NewWebserverConfigResource,
+		NewDomainResource,
+		NewWebInterfaceResource,
+		NewRateLimitResource,
+		NewSpecialDomainsResource,
+		NewDatabaseConfigResource,
+		NewBlockingModeResource,
+		NewGravityResource,
+		NewCustomDNSConfigResource,
+		NewSessionResource,
+		NewAPISettingsResource,
+		NewAdlistsToggleResource,
+		NewWebPasswordResource,
+		NewDNSSettingsResource,
+		NewQueryLoggingResource,
+		NewWebserverPortResource,
+		NewAdlistsResource,
+		NewEDNSConfigResource,
+		NewDomainsFromFileResource,
 	}
 }
 
@@ -195,6 +398,15 @@ func (p *PiholeProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewDNSRecordDataSource,
 		NewCNAMERecordDataSource,
 		NewConfigDataSource,
+		NewSessionsDataSource,
+		NewNetworkGatewayDataSource,
+		NewZoneFileDataSource,
+		NewConfigKeyExistsDataSource,
+		NewGravityInfoDataSource,
+		NewImportScriptDataSource,
+		NewDHCPConfigDataSource,
+		NewInventoryDataSource,
+		NewHostInfoDataSource,
 	}
 }
 
@@ -205,3 +417,42 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// clientErrorDiagnostic explains why the Pi-hole API client could not be created. Authentication problems with
+// a known cause get a tailored summary and remedy, everything else is reported as is.
+func clientErrorDiagnostic(err error) (string, string) {
+	switch {
+	case errors.Is(err, ErrSessionLimitExceeded):
+		return "Pi-hole API Session Limit Reached",
+			"Pi-hole refused to open another API session because all session seats are in use. " +
+				"This is not a password problem: every Terraform run opens its own session, and sessions of earlier runs, " +
+				"other tools or browser logins stay open until they time out.\n\n" +
+				"To resolve this, wait for stale sessions to expire (webserver.session.timeout), log out unused sessions " +
+				"in the Pi-hole web interface (Settings > Web interface / API > Currently active sessions), avoid running " +
+				"several Terraform runs against the same Pi-hole in parallel, or raise webserver.api.max_sessions.\n\n" +
+				"Pi-hole Client Error: " + err.Error()
+	case errors.Is(err, ErrInvalidPassword):
+		return "Invalid Pi-hole Password",
+			"Pi-hole rejected the configured password. Check that the provider's password attribute matches " +
+				"the admin password or an application password of the Pi-hole.\n\n" +
+				"Pi-hole Client Error: " + err.Error()
+	case errors.Is(err, ErrAuthUnreachable):
+		return "Pi-hole API Unreachable",
+			"The provider could not connect to Pi-hole to authenticate. This is not a password problem: the request " +
+				"didn't get an answer at all. Check that the url, including scheme and port, points at the Pi-hole web " +
+				"interface, that Pi-hole is running and reachable from this machine, and, for https, that its " +
+				"certificate is trusted.\n\n" +
+				"Pi-hole Client Error: " + err.Error()
+	case errors.Is(err, ErrEmptyResponse):
+		return "Empty Pi-hole API Response",
+			"Pi-hole answered the authentication request with an empty response. This usually means that the url " +
+				"points at a reverse proxy or web server that doesn't forward requests to the Pi-hole API. Check that " +
+				"the url is the address of the Pi-hole web interface, without a path, and that /api/auth is reachable.\n\n" +
+				"Pi-hole Client Error: " + err.Error()
+	default:
+		return "Unable to Create Pi-hole API Client",
+			"An unexpected error occurred when creating the Pi-hole API client. " +
+				"If the error is not clear, please contact the provider developers.\n\n" +
+				"Pi-hole Client Error: " + err.Error()
+	}
+}
@@ -3,12 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -130,6 +137,28 @@ func TestPiholeProvider_Schema(t *testing.T) {
 	if _, exists := resp.Schema.Attributes["retry_backoff_base_ms"]; !exists {
 		t.Error("Provider schema should have 'retry_backoff_base_ms' attribute")
 	}
+
+	if _, exists := resp.Schema.Attributes["retry_max_backoff_ms"]; !exists {
+		t.Error("Provider schema should have 'retry_max_backoff_ms' attribute")
+	}
+
+	for _, name := range []string{"read_retry_attempts", "write_retry_attempts"} {
+		if _, exists := resp.Schema.Attributes[name]; !exists {
+			t.Errorf("Provider schema should have '%s' attribute", name)
+		}
+	}
+
+	if _, exists := resp.Schema.Attributes["min_tls_version"]; !exists {
+		t.Error("Provider schema should have 'min_tls_version' attribute")
+	}
+
+	if _, exists := resp.Schema.Attributes["disable_keep_alives"]; !exists {
+		t.Error("Provider schema should have 'disable_keep_alives' attribute")
+	}
+
+	if _, exists := resp.Schema.Attributes["default_group_ids"]; !exists {
+		t.Error("Provider schema should have 'default_group_ids' attribute")
+	}
 }
 
 func TestPiholeProvider_Metadata(t *testing.T) {
@@ -156,8 +185,8 @@ func TestPiholeProvider_Resources(t *testing.T) {
 
 	resources := provider.Resources(ctx)
 
-	if len(resources) != 3 {
-		t.Errorf("Expected 3 resources, got %d", len(resources))
+	if len(resources) != 23 {
+		t.Errorf("Expected 23 resources, got %d", len(resources))
 	}
 
 	// Test that resource functions can be called without panic
@@ -179,9 +208,10 @@ func TestPiholeProvider_DataSources(t *testing.T) {
 
 	dataSources := provider.DataSources(ctx)
 
-	// Should have 5 data sources: dns_records, cname_records, dns_record, cname_record, config
-	if len(dataSources) != 5 {
-		t.Errorf("Expected 5 data sources, got %d", len(dataSources))
+	// Should have 14 data sources: dns_records, cname_records, dns_record, cname_record, config, sessions, network_gateway,
+	// zone_file, config_key_exists, gravity_info, import_script, dhcp_config, inventory, host_info
+	if len(dataSources) != 14 {
+		t.Errorf("Expected 14 data sources, got %d", len(dataSources))
 	}
 }
 
@@ -202,7 +232,7 @@ func TestClientCaching(t *testing.T) {
 
 	// First call should create new client
 	initialCacheSize := getCacheSize()
-	client1, err := getOrCreateClient(server.URL, "password1", config)
+	client1, err := getOrCreateClient(context.Background(), server.URL, "password1", config)
 	if err != nil {
 		t.Fatalf("Failed to create first client: %v", err)
 	}
@@ -212,7 +242,7 @@ func TestClientCaching(t *testing.T) {
 	}
 
 	// Second call with same URL/password should reuse client
-	client2, err := getOrCreateClient(server.URL, "password1", config)
+	client2, err := getOrCreateClient(context.Background(), server.URL, "password1", config)
 	if err != nil {
 		t.Fatalf("Failed to get cached client: %v", err)
 	}
@@ -226,7 +256,7 @@ func TestClientCaching(t *testing.T) {
 	}
 
 	// Third call with different password should create new client
-	client3, err := getOrCreateClient(server.URL, "password2", config)
+	client3, err := getOrCreateClient(context.Background(), server.URL, "password2", config)
 	if err != nil {
 		t.Fatalf("Failed to create third client: %v", err)
 	}
@@ -242,3 +272,281 @@ func TestClientCaching(t *testing.T) {
 	// Clean up
 	clearClientCache()
 }
+
+// overlappingAuthServer delegates to a fake Pi-hole, holding each login until the given number of logins are in
+// flight at once or the timeout passes. overlapped reports whether all of them were in flight together.
+func overlappingAuthServer(fake *fakePihole, inFlight *int32, expected int32, allArrived chan struct{}, overlapped *atomic.Bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/api/auth" {
+			if atomic.AddInt32(inFlight, 1) == expected {
+				overlapped.Store(true)
+				close(allArrived)
+			}
+			select {
+			case <-allArrived:
+			case <-time.After(2 * time.Second):
+			}
+			atomic.AddInt32(inFlight, -1)
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+}
+
+func TestClientCaching_DifferentURLsAuthenticateInParallel(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	fake := createMockPiholeServer()
+	defer fake.Close()
+
+	var inFlight int32
+	var overlapped atomic.Bool
+	allArrived := make(chan struct{})
+	server1 := overlappingAuthServer(fake, &inFlight, 2, allArrived, &overlapped)
+	defer server1.Close()
+	server2 := overlappingAuthServer(fake, &inFlight, 2, allArrived, &overlapped)
+	defer server2.Close()
+
+	config := ClientConfig{MaxConnections: 1, RetryAttempts: 1, RetryBackoffMs: 10}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, url := range []string{server1.URL, server2.URL} {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			_, errs[i] = getOrCreateClient(context.Background(), url, "password", config)
+		}(i, url)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to create client %d: %v", i+1, err)
+		}
+	}
+	// Serialized creation would hold the first login until the timeout without the second one arriving
+	if !overlapped.Load() {
+		t.Error("Expected the logins to both Pi-holes to overlap")
+	}
+	if getCacheSize() != 2 {
+		t.Errorf("Expected 2 cached clients, got %d", getCacheSize())
+	}
+}
+
+func TestClientCaching_ConcurrentCallsShareOneClient(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	config := ClientConfig{MaxConnections: 1, RetryAttempts: 1, RetryBackoffMs: 10}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	clients := make([]*PiholeClient, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := getOrCreateClient(context.Background(), server.URL, "password", config)
+			if err != nil {
+				t.Errorf("Failed to create client: %v", err)
+			}
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	for _, client := range clients[1:] {
+		if client != clients[0] {
+			t.Fatal("Expected all callers to get the same client instance")
+		}
+	}
+	if auths := server.requestCount("POST /api/auth"); auths != 1 {
+		t.Errorf("Expected 1 login for concurrent calls with the same key, got %d", auths)
+	}
+}
+
+func TestClientCaching_AliasesWithDifferentSettings(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	// Two provider aliases for the same Pi-hole, only one of them verifying writes
+	configure := func(verifyWrites bool) *PiholeClient {
+		resp := testProviderConfigure(t, map[string]tftypes.Value{
+			"url":              tftypes.NewValue(tftypes.String, server.URL),
+			"password":         tftypes.NewValue(tftypes.String, "test-password"),
+			"request_delay_ms": tftypes.NewValue(tftypes.Number, 0),
+			"verify_writes":    tftypes.NewValue(tftypes.Bool, verifyWrites),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected Configure to succeed, got %v", resp.Diagnostics.Errors())
+		}
+		return resp.ResourceData.(*PiholeClient)
+	}
+
+	verifying := configure(true)
+	plain := configure(false)
+
+	if verifying == plain {
+		t.Fatal("Expected aliases with different settings to get their own client")
+	}
+	if !verifying.Config.VerifyWrites || plain.Config.VerifyWrites {
+		t.Errorf("Expected each client to keep the verify_writes of its alias, got %v and %v",
+			verifying.Config.VerifyWrites, plain.Config.VerifyWrites)
+	}
+	// An alias with the same settings still shares the client
+	if configure(true) != verifying {
+		t.Error("Expected aliases with the same settings to share the client")
+	}
+}
+
+func TestClientCaching_AliasesWithDifferentRetryAttempts(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	configure := func(readRetries, writeRetries int) *PiholeClient {
+		resp := testProviderConfigure(t, map[string]tftypes.Value{
+			"url":                  tftypes.NewValue(tftypes.String, server.URL),
+			"password":             tftypes.NewValue(tftypes.String, "test-password"),
+			"request_delay_ms":     tftypes.NewValue(tftypes.Number, 0),
+			"read_retry_attempts":  tftypes.NewValue(tftypes.Number, readRetries),
+			"write_retry_attempts": tftypes.NewValue(tftypes.Number, writeRetries),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected Configure to succeed, got %v", resp.Diagnostics.Errors())
+		}
+		return resp.ResourceData.(*PiholeClient)
+	}
+
+	reads := configure(5, 0)
+	writes := configure(0, 5)
+
+	if reads == writes {
+		t.Fatal("Expected aliases with different retry attempts to get their own client")
+	}
+	if reads.retryAttempts("GET") != 5 || reads.retryAttempts("POST") != 0 {
+		t.Errorf("Expected 5 read and 0 write retries, got %d and %d", reads.retryAttempts("GET"), reads.retryAttempts("POST"))
+	}
+	if writes.retryAttempts("GET") != 0 || writes.retryAttempts("POST") != 5 {
+		t.Errorf("Expected 0 read and 5 write retries, got %d and %d", writes.retryAttempts("GET"), writes.retryAttempts("POST"))
+	}
+}
+
+// testProviderConfig builds a raw provider configuration with the given attributes, leaving unspecified attributes null
+func testProviderConfig(t *testing.T, attrs map[string]tftypes.Value) (provider.SchemaResponse, tftypes.Value) {
+	t.Helper()
+
+	schemaResp := provider.SchemaResponse{}
+	New("test")().Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, exists := attrs[name]; exists {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return schemaResp, tftypes.NewValue(objectType, values)
+}
+
+// testProviderConfigure runs the provider's Configure with the given attributes, leaving unspecified attributes null
+func testProviderConfigure(t *testing.T, attrs map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	schemaResp, raw := testProviderConfig(t, attrs)
+	resp := &provider.ConfigureResponse{}
+	New("test")().Configure(context.Background(), provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+	}, resp)
+
+	return resp
+}
+
+func TestPiholeProvider_InsecureTLSWarning(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name        string
+		insecureTLS tftypes.Value
+		expectWarn  bool
+	}{
+		{"unset", tftypes.NewValue(tftypes.Bool, nil), false},
+		{"disabled", tftypes.NewValue(tftypes.Bool, false), false},
+		{"enabled", tftypes.NewValue(tftypes.Bool, true), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"url":              tftypes.NewValue(tftypes.String, server.URL),
+				"password":         tftypes.NewValue(tftypes.String, "test-password"),
+				"request_delay_ms": tftypes.NewValue(tftypes.Number, 0),
+				"insecure_tls":     tc.insecureTLS,
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected Configure to succeed, got %v", resp.Diagnostics.Errors())
+			}
+
+			warned := false
+			for _, warning := range resp.Diagnostics.Warnings() {
+				if warning.Summary() == "TLS Certificate Verification Disabled" {
+					warned = true
+				}
+			}
+			if warned != tc.expectWarn {
+				t.Errorf("Expected insecure TLS warning %v, got %v", tc.expectWarn, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func TestPiholeProvider_ValidationReportsAllInvalidAttributes(t *testing.T) {
+	_, raw := testProviderConfig(t, map[string]tftypes.Value{
+		"url":              tftypes.NewValue(tftypes.String, "https://pihole.example.com"),
+		"password":         tftypes.NewValue(tftypes.String, "test-password"),
+		"max_connections":  tftypes.NewValue(tftypes.Number, -1),
+		"request_delay_ms": tftypes.NewValue(tftypes.Number, -300),
+		"min_tls_version":  tftypes.NewValue(tftypes.String, "1.1"),
+	})
+	config, err := tfprotov6.NewDynamicValue(raw.Type(), raw)
+	if err != nil {
+		t.Fatalf("Failed to encode provider configuration: %v", err)
+	}
+
+	server := providerserver.NewProtocol6(New("test")())()
+	resp, err := server.ValidateProviderConfig(context.Background(), &tfprotov6.ValidateProviderConfigRequest{Config: &config})
+	if err != nil {
+		t.Fatalf("ValidateProviderConfig failed: %v", err)
+	}
+
+	invalid := make(map[string]bool)
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError && diagnostic.Attribute != nil {
+			invalid[diagnostic.Attribute.String()] = true
+		}
+	}
+	for _, attribute := range []string{"max_connections", "request_delay_ms", "min_tls_version"} {
+		if !invalid[tftypes.NewAttributePath().WithAttributeName(attribute).String()] {
+			t.Errorf("Expected an error for %s, got %v", attribute, resp.Diagnostics)
+		}
+	}
+}
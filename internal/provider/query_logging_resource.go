@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &QueryLoggingResource{}
+
+func NewQueryLoggingResource() resource.Resource {
+	return &QueryLoggingResource{}
+}
+
+type QueryLoggingResource struct {
+	client *PiholeClient
+}
+
+type QueryLoggingResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *QueryLoggingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_logging"
+}
+
+func (r *QueryLoggingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages whether FTL logs DNS queries (`dns.queryLogging`). " +
+			"While query logging is disabled, queries don't show up in the query log and the statistics of the " +
+			"web interface and the API, e.g. the dashboard counters, stop growing. " +
+			"Deleting this resource restores Pi-hole's default of logging queries.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Query logging identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether FTL logs DNS queries",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *QueryLoggingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *QueryLoggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data QueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"queryLogging": data.Enabled.ValueBool()}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set query logging, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query logging, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QueryLoggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data QueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query logging, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QueryLoggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data QueryLoggingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"queryLogging": data.Enabled.ValueBool()}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update query logging, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query logging, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QueryLoggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole default rather than leaving query logging disabled
+	if err := r.client.SetConfigSection("dns", map[string]interface{}{"queryLogging": true}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset query logging, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the query logging setting currently active in Pi-hole
+func (r *QueryLoggingResource) readInto(data *QueryLoggingResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	enabled, ok := dnsConfig["queryLogging"].(bool)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.queryLogging: %v", dnsConfig["queryLogging"])
+	}
+
+	data.ID = types.StringValue("query_logging")
+	data.Enabled = types.BoolValue(enabled)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// queryLoggingFakeSections is a dns section with query logging next to unrelated settings
+func queryLoggingFakeSections(enabled bool) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"port":         53,
+			"queryLogging": enabled,
+		},
+	}
+}
+
+func TestQueryLoggingResource_Metadata(t *testing.T) {
+	r := NewQueryLoggingResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_query_logging" {
+		t.Errorf("Expected TypeName to be 'pihole_query_logging', got '%s'", resp.TypeName)
+	}
+}
+
+func TestQueryLoggingResource_Toggle(t *testing.T) {
+	server := newFakePihole(queryLoggingFakeSections(true))
+	defer server.Close()
+
+	r := NewQueryLoggingResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	if dnsConfig["queryLogging"] != false {
+		t.Errorf("Expected dns.queryLogging to be disabled, got %v", dnsConfig["queryLogging"])
+	}
+	// Unrelated dns settings must be left untouched by the PATCH
+	if dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns.port to be preserved, got %v", dnsConfig["port"])
+	}
+
+	updateResp := testResourceUpdate(t, r, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "query_logging"),
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+	}, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "query_logging"),
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", updateResp.Diagnostics.Errors())
+	}
+
+	if server.section("dns")["queryLogging"] != true {
+		t.Errorf("Expected dns.queryLogging to be enabled again, got %v", server.section("dns")["queryLogging"])
+	}
+
+	var enabled types.Bool
+	updateResp.State.GetAttribute(context.Background(), path.Root("enabled"), &enabled)
+	if !enabled.ValueBool() {
+		t.Errorf("Expected enabled to be true in state, got %s", enabled)
+	}
+}
+
+func TestQueryLoggingResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(queryLoggingFakeSections(false))
+	defer server.Close()
+
+	r := NewQueryLoggingResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "query_logging"),
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var enabled types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("enabled"), &enabled)
+	if enabled.ValueBool() {
+		t.Error("Expected state to reflect that query logging was disabled in Pi-hole")
+	}
+}
+
+func TestQueryLoggingResource_DeleteRestoresDefault(t *testing.T) {
+	server := newFakePihole(queryLoggingFakeSections(false))
+	defer server.Close()
+
+	r := NewQueryLoggingResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "query_logging"),
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if server.section("dns")["queryLogging"] != true {
+		t.Errorf("Expected dns.queryLogging to be reset to true, got %v", server.section("dns")["queryLogging"])
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RateLimitResource{}
+
+// Pi-hole's defaults for dns.rateLimit, restored when the resource is deleted
+const (
+	defaultRateLimitCount    = 1000
+	defaultRateLimitInterval = 60
+)
+
+func NewRateLimitResource() resource.Resource {
+	return &RateLimitResource{}
+}
+
+type RateLimitResource struct {
+	client *PiholeClient
+}
+
+type RateLimitResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Count           types.Int64  `tfsdk:"count"`
+	IntervalSeconds types.Int64  `tfsdk:"interval_seconds"`
+}
+
+func (r *RateLimitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rate_limit"
+}
+
+func (r *RateLimitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages how FTL rate-limits DNS queries per client (`dns.rateLimit.count` and `dns.rateLimit.interval`). " +
+			"Clients sending more than `count` queries within `interval_seconds` are blocked until the interval has passed. " +
+			"Deleting this resource restores Pi-hole's default of 1000 queries per 60 seconds.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Rate limit identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of queries a client may send within the interval. `0` disables rate-limiting.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"interval_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Length of the rate-limiting interval in seconds",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *RateLimitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RateLimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RateLimitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", rateLimitValues(data.Count.ValueInt64(), data.IntervalSeconds.ValueInt64())); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set rate limit, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rate limit, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RateLimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RateLimitResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rate limit, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RateLimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RateLimitResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", rateLimitValues(data.Count.ValueInt64(), data.IntervalSeconds.ValueInt64())); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update rate limit, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rate limit, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RateLimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.client.SetConfigSection("dns", rateLimitValues(defaultRateLimitCount, defaultRateLimitInterval))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset rate limit, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the rate limit currently active in Pi-hole
+func (r *RateLimitResource) readInto(data *RateLimitResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	rateLimit, ok := dnsConfig["rateLimit"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.rateLimit: %v", dnsConfig["rateLimit"])
+	}
+	count, ok := rateLimit["count"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.rateLimit.count: %v", rateLimit["count"])
+	}
+	interval, ok := rateLimit["interval"].(float64)
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.rateLimit.interval: %v", rateLimit["interval"])
+	}
+
+	data.ID = types.StringValue("rate_limit")
+	data.Count = types.Int64Value(int64(count))
+	data.IntervalSeconds = types.Int64Value(int64(interval))
+
+	return nil
+}
+
+// rateLimitValues builds the dns section payload for the given rate limit
+func rateLimitValues(count, interval int64) map[string]interface{} {
+	return map[string]interface{}{
+		"rateLimit": map[string]interface{}{
+			"count":    count,
+			"interval": interval,
+		},
+	}
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// rateLimitFakeSections is a dns section with a rate limit next to unrelated settings
+func rateLimitFakeSections(count, interval int) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"port":      53,
+			"rateLimit": map[string]interface{}{"count": count, "interval": interval},
+		},
+	}
+}
+
+func TestRateLimitResource_Schema(t *testing.T) {
+	r := NewRateLimitResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	for _, name := range []string{"count", "interval_seconds"} {
+		attr, exists := schemaResp.Schema.Attributes[name]
+		if !exists {
+			t.Errorf("Schema should have '%s' attribute", name)
+		} else if !attr.IsRequired() {
+			t.Errorf("'%s' attribute should be required", name)
+		}
+	}
+}
+
+func TestRateLimitResource_Metadata(t *testing.T) {
+	r := NewRateLimitResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_rate_limit" {
+		t.Errorf("Expected TypeName to be 'pihole_rate_limit', got '%s'", resp.TypeName)
+	}
+}
+
+func TestRateLimitResource_Validation(t *testing.T) {
+	r := NewRateLimitResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	testCases := []struct {
+		attribute string
+		value     int64
+		expectErr bool
+	}{
+		{"count", 1000, false},
+		{"count", 0, false},
+		{"count", -1, true},
+		{"interval_seconds", 60, false},
+		{"interval_seconds", 0, false},
+		{"interval_seconds", -60, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s=%d", tc.attribute, tc.value), func(t *testing.T) {
+			attr := schemaResp.Schema.Attributes[tc.attribute].(schema.Int64Attribute)
+			req := validator.Int64Request{
+				Path:        path.Root(tc.attribute),
+				ConfigValue: types.Int64Value(tc.value),
+			}
+			resp := &validator.Int64Response{}
+			for _, v := range attr.Validators {
+				v.ValidateInt64(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For %s=%d: expected error %v, got %v", tc.attribute, tc.value, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestRateLimitResource_SetLimit(t *testing.T) {
+	server := newFakePihole(rateLimitFakeSections(1000, 60))
+	defer server.Close()
+
+	r := NewRateLimitResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"count":            tftypes.NewValue(tftypes.Number, 5000),
+		"interval_seconds": tftypes.NewValue(tftypes.Number, 30),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	rateLimit := dnsConfig["rateLimit"].(map[string]interface{})
+	if rateLimit["count"] != 5000.0 || rateLimit["interval"] != 30.0 {
+		t.Errorf("Expected rate limit 5000/30, got %v/%v", rateLimit["count"], rateLimit["interval"])
+	}
+	// Unrelated dns settings must be left untouched by the PATCH
+	if dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns.port to be preserved, got %v", dnsConfig["port"])
+	}
+
+	var count, interval types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("count"), &count)
+	resp.State.GetAttribute(context.Background(), path.Root("interval_seconds"), &interval)
+	if count.ValueInt64() != 5000 || interval.ValueInt64() != 30 {
+		t.Errorf("Expected state 5000/30, got %d/%d", count.ValueInt64(), interval.ValueInt64())
+	}
+}
+
+func TestRateLimitResource_Disable(t *testing.T) {
+	server := newFakePihole(rateLimitFakeSections(1000, 60))
+	defer server.Close()
+
+	r := NewRateLimitResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	prior := map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, "rate_limit"),
+		"count":            tftypes.NewValue(tftypes.Number, 1000),
+		"interval_seconds": tftypes.NewValue(tftypes.Number, 60),
+	}
+	resp := testResourceUpdate(t, r, prior, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, "rate_limit"),
+		"count":            tftypes.NewValue(tftypes.Number, 0),
+		"interval_seconds": tftypes.NewValue(tftypes.Number, 60),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	rateLimit := server.section("dns")["rateLimit"].(map[string]interface{})
+	if rateLimit["count"] != 0.0 {
+		t.Errorf("Expected rate-limiting to be disabled (count 0), got %v", rateLimit["count"])
+	}
+
+	var count types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("count"), &count)
+	if count.IsNull() || count.ValueInt64() != 0 {
+		t.Errorf("Expected count 0 in state, got %s", count)
+	}
+}
+
+func TestRateLimitResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(rateLimitFakeSections(200, 10))
+	defer server.Close()
+
+	r := NewRateLimitResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, "rate_limit"),
+		"count":            tftypes.NewValue(tftypes.Number, 1000),
+		"interval_seconds": tftypes.NewValue(tftypes.Number, 60),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var count, interval types.Int64
+	resp.State.GetAttribute(context.Background(), path.Root("count"), &count)
+	resp.State.GetAttribute(context.Background(), path.Root("interval_seconds"), &interval)
+	if count.ValueInt64() != 200 || interval.ValueInt64() != 10 {
+		t.Errorf("Expected state to reflect Pi-hole (200/10), got %d/%d", count.ValueInt64(), interval.ValueInt64())
+	}
+}
+
+func TestRateLimitResource_DeleteRestoresDefaults(t *testing.T) {
+	server := newFakePihole(rateLimitFakeSections(0, 60))
+	defer server.Close()
+
+	r := NewRateLimitResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, "rate_limit"),
+		"count":            tftypes.NewValue(tftypes.Number, 0),
+		"interval_seconds": tftypes.NewValue(tftypes.Number, 60),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	rateLimit := server.section("dns")["rateLimit"].(map[string]interface{})
+	if rateLimit["count"] != 1000.0 || rateLimit["interval"] != 60.0 {
+		t.Errorf("Expected rate limit to be reset to 1000/60, got %v/%v", rateLimit["count"], rateLimit["interval"])
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testResourceObject builds a raw value for the resource schema, leaving unspecified attributes null
+func testResourceObject(t *testing.T, r resource.Resource, attrs map[string]tftypes.Value) (resource.SchemaResponse, tftypes.Value) {
+	t.Helper()
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema type to be an object")
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, exists := attrs[name]; exists {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return schemaResp, tftypes.NewValue(objectType, values)
+}
+
+// testConfigureResource wires the client into the resource like the provider would
+func testConfigureResource(t *testing.T, r resource.Resource, client *PiholeClient) {
+	t.Helper()
+
+	configurable, ok := r.(resource.ResourceWithConfigure)
+	if !ok {
+		t.Fatalf("Resource does not implement Configure")
+	}
+
+	resp := &resource.ConfigureResponse{}
+	configurable.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure has errors: %v", resp.Diagnostics.Errors())
+	}
+}
+
+// testResourceCreate runs Create with the given planned attributes
+func testResourceCreate(t *testing.T, r resource.Resource, planned map[string]tftypes.Value) *resource.CreateResponse {
+	t.Helper()
+
+	schemaResp, plan := testResourceObject(t, r, planned)
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(plan.Type(), nil)},
+	}
+	r.Create(context.Background(), resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}, resp)
+
+	return resp
+}
+
+// testResourceRead runs Read with the given prior state attributes
+func testResourceRead(t *testing.T, r resource.Resource, prior map[string]tftypes.Value) *resource.ReadResponse {
+	t.Helper()
+
+	schemaResp, state := testResourceObject(t, r, prior)
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	r.Read(context.Background(), resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	return resp
+}
+
+// testResourceUpdate runs Update from the given prior state to the given planned attributes
+func testResourceUpdate(t *testing.T, r resource.Resource, prior, planned map[string]tftypes.Value) *resource.UpdateResponse {
+	t.Helper()
+
+	schemaResp, state := testResourceObject(t, r, prior)
+	_, plan := testResourceObject(t, r, planned)
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	return resp
+}
+
+// testResourceDelete runs Delete with the given prior state attributes
+func testResourceDelete(t *testing.T, r resource.Resource, prior map[string]tftypes.Value) *resource.DeleteResponse {
+	t.Helper()
+
+	schemaResp, state := testResourceObject(t, r, prior)
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	r.Delete(context.Background(), resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	return resp
+}
+
+// testResourceModifyPlan runs ModifyPlan for the given planned attributes. A nil prior state plans a create.
+func testResourceModifyPlan(t *testing.T, r resource.Resource, prior, planned map[string]tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	modifiable, ok := r.(resource.ResourceWithModifyPlan)
+	if !ok {
+		t.Fatalf("Resource does not implement ModifyPlan")
+	}
+
+	schemaResp, plan := testResourceObject(t, r, planned)
+	state := tftypes.NewValue(plan.Type(), nil)
+	if prior != nil {
+		_, state = testResourceObject(t, r, prior)
+	}
+
+	resp := &resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	modifiable.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	return resp
+}
+
+// testResourceValidateConfig runs ValidateConfig with the given configured attributes
+func testResourceValidateConfig(t *testing.T, r resource.Resource, config map[string]tftypes.Value) *resource.ValidateConfigResponse {
+	t.Helper()
+
+	validatable, ok := r.(resource.ResourceWithValidateConfig)
+	if !ok {
+		t.Fatalf("Resource does not implement ValidateConfig")
+	}
+
+	schemaResp, raw := testResourceObject(t, r, config)
+	resp := &resource.ValidateConfigResponse{}
+	validatable.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+	}, resp)
+
+	return resp
+}
+
+// testDataSourceRead runs Read with the given configured attributes, leaving unspecified attributes null
+func testDataSourceRead(t *testing.T, d datasource.DataSource, config map[string]tftypes.Value) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, exists := config[name]; exists {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(context.Background(), datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+
+	return resp
+}
+
+func newTestClient(t *testing.T, serverURL string) *PiholeClient {
+	t.Helper()
+
+	client, err := NewPiholeClient(serverURL, "test-password", ClientConfig{
+		MaxConnections: 1,
+		RequestDelayMs: 0,
+		RetryAttempts:  1,
+		RetryBackoffMs: 10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	return client
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SessionResource{}
+var _ resource.ResourceWithImportState = &SessionResource{}
+
+func NewSessionResource() resource.Resource {
+	return &SessionResource{}
+}
+
+type SessionResource struct {
+	client *PiholeClient
+}
+
+type SessionResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	SessionID  types.Int64  `tfsdk:"session_id"`
+	RemoteAddr types.String `tfsdk:"remote_addr"`
+	UserAgent  types.String `tfsdk:"user_agent"`
+}
+
+func (r *SessionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+func (r *SessionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tracks an existing Pi-hole API session so it can be revoked. Creating the resource only adopts the session; " +
+			"destroying it revokes the session in Pi-hole. Use the `pihole_sessions` data source to find leaked sessions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Session identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"session_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the session to revoke on destroy, as listed by the `pihole_sessions` data source",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"remote_addr": schema.StringAttribute{
+				MarkdownDescription: "The address the session was opened from",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_agent": schema.StringAttribute{
+				MarkdownDescription: "The user agent of the session",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SessionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SessionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SessionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := r.findSession(data.SessionID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sessions, got error: %s", err))
+		return
+	}
+
+	if session == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("session_id"),
+			"Session Not Found",
+			fmt.Sprintf("Pi-hole has no session with ID %d. It may already have expired.", data.SessionID.ValueInt64()),
+		)
+		return
+	}
+
+	setSessionModel(&data, session)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SessionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := r.findSession(data.SessionID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sessions, got error: %s", err))
+		return
+	}
+
+	// Expired or revoked elsewhere, there is nothing left to revoke
+	if session == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setSessionModel(&data, session)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// session_id requires replacement and all other attributes are computed, so there is nothing to update
+	var data SessionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SessionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := r.findSession(data.SessionID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sessions, got error: %s", err))
+		return
+	}
+
+	if session == nil {
+		return
+	}
+
+	// Revoking the provider's own session would fail every request that follows in this run
+	if session.CurrentSession {
+		resp.Diagnostics.AddError(
+			"Cannot Revoke Current Session",
+			fmt.Sprintf("Session %d is the session this provider uses. It is closed when Terraform finishes.", session.ID),
+		)
+		return
+	}
+
+	if err := r.client.DeleteSession(session.ID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke session, got error: %s", err))
+		return
+	}
+}
+
+func (r *SessionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sessionID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil || sessionID < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a session ID as listed by the pihole_sessions data source (e.g. '3'), got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("session_id"), sessionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// findSession returns the session with the given ID, or nil if Pi-hole doesn't know it (anymore)
+func (r *SessionResource) findSession(id int64) (*Session, error) {
+	sessions, err := r.client.GetSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		if sessions[i].ID == id {
+			return &sessions[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setSessionModel copies the session details into the model
+func setSessionModel(data *SessionResourceModel, session *Session) {
+	data.ID = types.StringValue(strconv.FormatInt(session.ID, 10))
+	data.SessionID = types.Int64Value(session.ID)
+	data.RemoteAddr = types.StringValue(session.RemoteAddr)
+	data.UserAgent = types.StringValue(session.UserAgent)
+}
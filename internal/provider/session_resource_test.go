@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testSessions are a leaked session of an earlier Terraform run and the provider's own session
+func testSessions() []Session {
+	leaked := Session{ID: 3, Valid: true, RemoteAddr: "192.168.1.50", UserAgent: "Go-http-client/1.1", LoginAt: 1760000000}
+	leaked.TLS.Login = true
+	current := Session{ID: 4, CurrentSession: true, Valid: true, RemoteAddr: "192.168.1.60", UserAgent: "Go-http-client/1.1", LoginAt: 1760003600}
+	return []Session{leaked, current}
+}
+
+func TestSessionsDataSource_ListsSessions(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()...)
+
+	d := NewSessionsDataSource()
+	d.(*SessionsDataSource).client = newTestClient(t, server.URL)
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(objectType.AttributeTypes["id"], nil),
+		"sessions":      tftypes.NewValue(objectType.AttributeTypes["sessions"], nil),
+		"session_count": tftypes.NewValue(objectType.AttributeTypes["session_count"], nil),
+	})
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(context.Background(), datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data SessionsDataSourceModel
+	resp.State.Get(context.Background(), &data)
+	if len(data.Sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(data.Sessions))
+	}
+	if data.SessionCount.ValueInt64() != 2 {
+		t.Errorf("Expected session_count 2, got %s", data.SessionCount)
+	}
+
+	leaked := data.Sessions[0]
+	if leaked.ID.ValueInt64() != 3 || leaked.CurrentSession.ValueBool() || !leaked.TLS.ValueBool() ||
+		leaked.RemoteAddr.ValueString() != "192.168.1.50" || leaked.LoginAt.ValueInt64() != 1760000000 {
+		t.Errorf("Unexpected first session: %+v", leaked)
+	}
+	if !data.Sessions[1].CurrentSession.ValueBool() {
+		t.Errorf("Expected the second session to be marked as the current one")
+	}
+}
+
+func TestSessionResource_DeleteRevokesSession(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()...)
+
+	r := NewSessionResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	createResp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"session_id": tftypes.NewValue(tftypes.Number, 3),
+	})
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", createResp.Diagnostics.Errors())
+	}
+	if ids := server.sessionIDs(); fmt.Sprint(ids) != "[3 4]" {
+		t.Errorf("Expected creating the resource to leave the sessions alone, got %v", ids)
+	}
+
+	deleteResp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "3"),
+		"session_id":  tftypes.NewValue(tftypes.Number, 3),
+		"remote_addr": tftypes.NewValue(tftypes.String, "192.168.1.50"),
+		"user_agent":  tftypes.NewValue(tftypes.String, "Go-http-client/1.1"),
+	})
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", deleteResp.Diagnostics.Errors())
+	}
+	if ids := server.sessionIDs(); fmt.Sprint(ids) != "[4]" {
+		t.Errorf("Expected only session 3 to be revoked, got %v", ids)
+	}
+}
+
+func TestSessionResource_RefusesToRevokeCurrentSession(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()...)
+
+	r := NewSessionResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "4"),
+		"session_id": tftypes.NewValue(tftypes.Number, 4),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error when revoking the provider's own session")
+	}
+	if ids := server.sessionIDs(); fmt.Sprint(ids) != "[3 4]" {
+		t.Errorf("Expected no session to be revoked, got %v", ids)
+	}
+}
+
+func TestSessionResource_CreateUnknownSession(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()...)
+
+	r := NewSessionResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"session_id": tftypes.NewValue(tftypes.Number, 9),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error for a session Pi-hole doesn't know")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Session Not Found" {
+		t.Errorf("Expected 'Session Not Found', got '%s'", summary)
+	}
+}
+
+func TestSessionResource_ReadRemovesExpiredSession(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()[1])
+
+	r := NewSessionResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "3"),
+		"session_id": tftypes.NewValue(tftypes.Number, 3),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("Expected the expired session to be removed from state")
+	}
+}
+
+func TestSessionResource_DeleteExpiredSession(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+	server.setSessions(testSessions()...)
+
+	r := NewSessionResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "7"),
+		"session_id": tftypes.NewValue(tftypes.Number, 7),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Expected deleting an expired session to succeed, got %v", resp.Diagnostics.Errors())
+	}
+	if ids := server.sessionIDs(); fmt.Sprint(ids) != "[3 4]" {
+		t.Errorf("Expected the other sessions to be left alone, got %v", ids)
+	}
+}
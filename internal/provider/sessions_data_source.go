@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SessionsDataSource{}
+
+func NewSessionsDataSource() datasource.DataSource {
+	return &SessionsDataSource{}
+}
+
+type SessionsDataSource struct {
+	client *PiholeClient
+}
+
+type SessionsDataSourceModel struct {
+	ID           types.String             `tfsdk:"id"`
+	Sessions     []SessionDataSourceModel `tfsdk:"sessions"`
+	SessionCount types.Int64              `tfsdk:"session_count"`
+}
+
+type SessionDataSourceModel struct {
+	ID             types.Int64  `tfsdk:"id"`
+	CurrentSession types.Bool   `tfsdk:"current_session"`
+	Valid          types.Bool   `tfsdk:"valid"`
+	TLS            types.Bool   `tfsdk:"tls"`
+	App            types.Bool   `tfsdk:"app"`
+	LoginAt        types.Int64  `tfsdk:"login_at"`
+	LastActive     types.Int64  `tfsdk:"last_active"`
+	RemoteAddr     types.String `tfsdk:"remote_addr"`
+	UserAgent      types.String `tfsdk:"user_agent"`
+}
+
+func (d *SessionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sessions"
+}
+
+func (d *SessionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the API sessions currently open on Pi-hole, e.g. to find sessions left behind by earlier Terraform runs",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"session_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of API sessions",
+				Computed:            true,
+			},
+			"sessions": schema.ListNestedAttribute{
+				MarkdownDescription: "List of API sessions",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The session ID",
+							Computed:            true,
+						},
+						"current_session": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is the session the provider itself uses",
+							Computed:            true,
+						},
+						"valid": schema.BoolAttribute{
+							MarkdownDescription: "Whether the session is still valid",
+							Computed:            true,
+						},
+						"tls": schema.BoolAttribute{
+							MarkdownDescription: "Whether the session logged in over TLS",
+							Computed:            true,
+						},
+						"app": schema.BoolAttribute{
+							MarkdownDescription: "Whether the session logged in with an application password",
+							Computed:            true,
+						},
+						"login_at": schema.Int64Attribute{
+							MarkdownDescription: "Login time (Unix timestamp)",
+							Computed:            true,
+						},
+						"last_active": schema.Int64Attribute{
+							MarkdownDescription: "Time of the last request (Unix timestamp)",
+							Computed:            true,
+						},
+						"remote_addr": schema.StringAttribute{
+							MarkdownDescription: "The address the session was opened from",
+							Computed:            true,
+						},
+						"user_agent": schema.StringAttribute{
+							MarkdownDescription: "The user agent of the session",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SessionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SessionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SessionsDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get sessions from Pi-hole
+	sessions, err := d.client.GetSessions()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read sessions: "+err.Error())
+		return
+	}
+
+	// Convert to data source model
+	sessionModels := make([]SessionDataSourceModel, 0, len(sessions))
+	for _, session := range sessions {
+		sessionModels = append(sessionModels, SessionDataSourceModel{
+			ID:             types.Int64Value(session.ID),
+			CurrentSession: types.BoolValue(session.CurrentSession),
+			Valid:          types.BoolValue(session.Valid),
+			TLS:            types.BoolValue(session.TLS.Login),
+			App:            types.BoolValue(session.App),
+			LoginAt:        types.Int64Value(session.LoginAt),
+			LastActive:     types.Int64Value(session.LastActive),
+			RemoteAddr:     types.StringValue(session.RemoteAddr),
+			UserAgent:      types.StringValue(session.UserAgent),
+		})
+	}
+
+	data.ID = types.StringValue("sessions")
+	data.SessionCount = types.Int64Value(int64(len(sessions)))
+	data.Sessions = sessionModels
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
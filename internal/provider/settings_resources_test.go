@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// unrelatedSettings are configuration values no settings resource manages. Every settings fixture
+// starts from them, so the resources can be checked to write their section without losing them.
+var unrelatedSettings = map[string]map[string]interface{}{
+	"dns": {
+		"domainNeeded": true,
+		"bogusPriv":    true,
+		"cache":        map[string]interface{}{"optimizer": 3600},
+		"blocking":     map[string]interface{}{"edns": "TEXT"},
+	},
+	"webserver": {
+		"acl":     "",
+		"paths":   map[string]interface{}{"webroot": "/var/www/html"},
+		"api":     map[string]interface{}{"app_sudo": true, "temp": map[string]interface{}{"limit": 60}},
+		"session": map[string]interface{}{"restore": true},
+	},
+	"misc": {
+		"nice":         -10,
+		"privacylevel": 0,
+	},
+	"database": {
+		"DBimport": true,
+		"network":  map[string]interface{}{"parseARPcache": true},
+	},
+}
+
+// settingsFakeSections returns a fake configuration with the managed values merged into the
+// unrelated settings of their section
+func settingsFakeSections(section string, managed map[string]interface{}) map[string]map[string]interface{} {
+	// Copy through JSON, so merging never changes unrelatedSettings
+	merged := make(map[string]interface{})
+	encoded, _ := json.Marshal(unrelatedSettings[section])
+	json.Unmarshal(encoded, &merged)
+
+	mergeSettings(merged, managed)
+	return map[string]map[string]interface{}{section: merged}
+}
+
+func mergeSettings(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if existing, ok := dst[key].(map[string]interface{}); ok {
+				mergeSettings(existing, nested)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// assertSettingsPreserved checks that every value of expected is still present in actual
+func assertSettingsPreserved(t *testing.T, path string, expected, actual map[string]interface{}) {
+	t.Helper()
+
+	for key, value := range expected {
+		if nested, ok := value.(map[string]interface{}); ok {
+			actualNested, ok := actual[key].(map[string]interface{})
+			if !ok {
+				t.Errorf("Expected %s.%s to be preserved, got %v", path, key, actual[key])
+				continue
+			}
+			assertSettingsPreserved(t, path+"."+key, nested, actualNested)
+			continue
+		}
+		if !reflect.DeepEqual(actual[key], value) {
+			t.Errorf("Expected %s.%s to be preserved as %v, got %v", path, key, value, actual[key])
+		}
+	}
+}
+
+func TestSettingsResources_PreserveUnrelatedSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource func() resource.Resource
+		section  string
+		sections map[string]map[string]interface{}
+		planned  map[string]tftypes.Value
+	}{
+		{
+			name:     "api_settings",
+			resource: NewAPISettingsResource,
+			section:  "webserver",
+			sections: settingsFakeSections("webserver", map[string]interface{}{
+				"api":     map[string]interface{}{"max_sessions": 16},
+				"session": map[string]interface{}{"timeout": 1800},
+			}),
+			planned: map[string]tftypes.Value{
+				"max_sessions":            tftypes.NewValue(tftypes.Number, 32),
+				"session_timeout_seconds": tftypes.NewValue(tftypes.Number, 300),
+			},
+		},
+		{
+			name:     "blocking_mode",
+			resource: NewBlockingModeResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{
+				"blocking": map[string]interface{}{"active": true, "mode": "NULL"},
+				"reply": map[string]interface{}{
+					"host":     map[string]interface{}{"force4": false, "IPv4": "", "force6": false, "IPv6": ""},
+					"blocking": map[string]interface{}{"force4": false, "IPv4": "", "force6": false, "IPv6": ""},
+				},
+			}),
+			planned: map[string]tftypes.Value{
+				"mode": tftypes.NewValue(tftypes.String, "NX"),
+			},
+		},
+		{
+			name:     "custom_dns_config",
+			resource: NewCustomDNSConfigResource,
+			section:  "misc",
+			sections: settingsFakeSections("misc", map[string]interface{}{
+				"dnsmasq_lines": []string{},
+			}),
+			planned: map[string]tftypes.Value{
+				"lines": testDnsmasqLinesValue("server=/corp.example/10.0.0.53"),
+			},
+		},
+		{
+			name:     "database_config",
+			resource: NewDatabaseConfigResource,
+			section:  "database",
+			sections: settingsFakeSections("database", map[string]interface{}{
+				"maxDBdays":  91,
+				"DBinterval": 60,
+			}),
+			planned: map[string]tftypes.Value{
+				"max_db_days": tftypes.NewValue(tftypes.Number, 30),
+				"db_interval": tftypes.NewValue(tftypes.Number, 300),
+			},
+		},
+		{
+			name:     "dns_settings",
+			resource: NewDNSSettingsResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{
+				"cache":           map[string]interface{}{"size": 10000},
+				"replyWhenBusy":   "ALLOW",
+				"port":            53,
+				"ignoreLocalhost": false,
+			}),
+			planned: map[string]tftypes.Value{
+				"cache_size": tftypes.NewValue(tftypes.Number, 50000),
+			},
+		},
+		{
+			name:     "edns_config",
+			resource: NewEDNSConfigResource,
+			section:  "dns",
+			sections: ednsConfigFakeSections(true),
+			planned: map[string]tftypes.Value{
+				"edns0_ecs": tftypes.NewValue(tftypes.Bool, false),
+			},
+		},
+		{
+			name:     "listening_config",
+			resource: NewListeningConfigResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{"listeningMode": "LOCAL", "interface": ""}),
+			planned: map[string]tftypes.Value{
+				"listening_mode": tftypes.NewValue(tftypes.String, "SINGLE"),
+				"interface":      tftypes.NewValue(tftypes.String, "eth0"),
+			},
+		},
+		{
+			name:     "query_logging",
+			resource: NewQueryLoggingResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{
+				"queryLogging": true,
+			}),
+			planned: map[string]tftypes.Value{
+				"enabled": tftypes.NewValue(tftypes.Bool, false),
+			},
+		},
+		{
+			name:     "rate_limit",
+			resource: NewRateLimitResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{
+				"rateLimit": map[string]interface{}{"count": 1000, "interval": 60},
+			}),
+			planned: map[string]tftypes.Value{
+				"count":            tftypes.NewValue(tftypes.Number, 5000),
+				"interval_seconds": tftypes.NewValue(tftypes.Number, 30),
+			},
+		},
+		{
+			name:     "special_domains",
+			resource: NewSpecialDomainsResource,
+			section:  "dns",
+			sections: settingsFakeSections("dns", map[string]interface{}{
+				"specialDomains": map[string]interface{}{
+					"mozillaCanary":      true,
+					"iCloudPrivateRelay": true,
+					"designatedResolver": true,
+				},
+			}),
+			planned: map[string]tftypes.Value{
+				"mozilla_canary":       tftypes.NewValue(tftypes.Bool, false),
+				"icloud_private_relay": tftypes.NewValue(tftypes.Bool, false),
+				"designated_resolver":  tftypes.NewValue(tftypes.Bool, false),
+			},
+		},
+		{
+			name:     "web_interface",
+			resource: NewWebInterfaceResource,
+			section:  "webserver",
+			sections: settingsFakeSections("webserver", map[string]interface{}{
+				"api":       map[string]interface{}{"temp": map[string]interface{}{"unit": "C"}},
+				"interface": map[string]interface{}{"theme": "default-auto", "boxed": true},
+			}),
+			planned: map[string]tftypes.Value{
+				"theme":        tftypes.NewValue(tftypes.String, "default-darker"),
+				"boxed_layout": tftypes.NewValue(tftypes.Bool, false),
+			},
+		},
+		{
+			name:     "web_password",
+			resource: NewWebPasswordResource,
+			section:  "webserver",
+			sections: settingsFakeSections("webserver", map[string]interface{}{
+				"api": map[string]interface{}{"password": "********"},
+			}),
+			planned: map[string]tftypes.Value{
+				"password": tftypes.NewValue(tftypes.String, "new-secret"),
+			},
+		},
+		{
+			name:     "webserver_port",
+			resource: NewWebserverPortResource,
+			section:  "webserver",
+			sections: settingsFakeSections("webserver", map[string]interface{}{
+				"port": defaultWebserverPort,
+			}),
+			planned: map[string]tftypes.Value{
+				"port": tftypes.NewValue(tftypes.String, "8080o,8443os"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakePihole(tt.sections)
+			defer server.Close()
+
+			r := tt.resource()
+			testConfigureResource(t, r, newTestClient(t, server.URL))
+
+			resp := testResourceCreate(t, r, tt.planned)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+			}
+
+			// The fake stores JSON-decoded values, so compare against the normalized fixture
+			expected := settingsFakeSections(tt.section, nil)[tt.section]
+			assertSettingsPreserved(t, tt.section, expected, server.section(tt.section))
+		})
+	}
+}
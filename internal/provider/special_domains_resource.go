@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SpecialDomainsResource{}
+
+// specialDomainKeys maps the resource attributes to their keys below dns.specialDomains
+var specialDomainKeys = map[string]string{
+	"mozilla_canary":       "mozillaCanary",
+	"icloud_private_relay": "iCloudPrivateRelay",
+	"designated_resolver":  "designatedResolver",
+}
+
+func NewSpecialDomainsResource() resource.Resource {
+	return &SpecialDomainsResource{}
+}
+
+type SpecialDomainsResource struct {
+	client *PiholeClient
+}
+
+type SpecialDomainsResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	MozillaCanary      types.Bool   `tfsdk:"mozilla_canary"`
+	ICloudPrivateRelay types.Bool   `tfsdk:"icloud_private_relay"`
+	DesignatedResolver types.Bool   `tfsdk:"designated_resolver"`
+}
+
+func (r *SpecialDomainsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_special_domains"
+}
+
+func (r *SpecialDomainsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages how Pi-hole answers special-purpose domains that let clients detect a filtering resolver " +
+			"(`dns.specialDomains`). Settings that are not set are left as they are in Pi-hole. " +
+			"Deleting this resource restores Pi-hole's defaults (all enabled).",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Special domains identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mozilla_canary": schema.BoolAttribute{
+				MarkdownDescription: "Block `use-application-dns.net` so Firefox doesn't automatically switch to DNS-over-HTTPS (`dns.specialDomains.mozillaCanary`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"icloud_private_relay": schema.BoolAttribute{
+				MarkdownDescription: "Block the iCloud Private Relay domains so Apple devices resolve through Pi-hole (`dns.specialDomains.iCloudPrivateRelay`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"designated_resolver": schema.BoolAttribute{
+				MarkdownDescription: "Block `resolver.arpa` so clients don't discover encrypted upstream resolvers (`dns.specialDomains.designatedResolver`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SpecialDomainsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SpecialDomainsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpecialDomainsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", specialDomainsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set special domains, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read special domains, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpecialDomainsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpecialDomainsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read special domains, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpecialDomainsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SpecialDomainsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetConfigSection("dns", specialDomainsValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update special domains, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read special domains, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpecialDomainsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	defaults := make(map[string]interface{}, len(specialDomainKeys))
+	for _, key := range specialDomainKeys {
+		defaults[key] = true
+	}
+
+	err := r.client.SetConfigSection("dns", map[string]interface{}{"specialDomains": defaults})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset special domains, got error: %s", err))
+		return
+	}
+}
+
+// readInto reconciles the model with the special domain settings currently active in Pi-hole
+func (r *SpecialDomainsResource) readInto(data *SpecialDomainsResourceModel) error {
+	dnsConfig, err := r.client.GetConfigSection("dns")
+	if err != nil {
+		return err
+	}
+
+	specialDomains, ok := dnsConfig["specialDomains"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected value for dns.specialDomains: %v", dnsConfig["specialDomains"])
+	}
+
+	values := make(map[string]types.Bool, len(specialDomainKeys))
+	for attribute, key := range specialDomainKeys {
+		value, ok := specialDomains[key].(bool)
+		if !ok {
+			return fmt.Errorf("unexpected value for dns.specialDomains.%s: %v", key, specialDomains[key])
+		}
+		values[attribute] = types.BoolValue(value)
+	}
+
+	data.ID = types.StringValue("special_domains")
+	data.MozillaCanary = values["mozilla_canary"]
+	data.ICloudPrivateRelay = values["icloud_private_relay"]
+	data.DesignatedResolver = values["designated_resolver"]
+
+	return nil
+}
+
+// specialDomainsValues builds the dns section payload from the planned model, leaving unset settings out
+func specialDomainsValues(data SpecialDomainsResourceModel) map[string]interface{} {
+	planned := map[string]types.Bool{
+		"mozilla_canary":       data.MozillaCanary,
+		"icloud_private_relay": data.ICloudPrivateRelay,
+		"designated_resolver":  data.DesignatedResolver,
+	}
+
+	values := make(map[string]interface{})
+	for attribute, value := range planned {
+		if !value.IsNull() && !value.IsUnknown() {
+			values[specialDomainKeys[attribute]] = value.ValueBool()
+		}
+	}
+
+	return map[string]interface{}{"specialDomains": values}
+}
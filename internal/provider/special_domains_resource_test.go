@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// specialDomainsFakeSections is a dns section with the special domains next to unrelated settings
+func specialDomainsFakeSections(mozillaCanary bool) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"dns": {
+			"port": 53,
+			"specialDomains": map[string]interface{}{
+				"mozillaCanary":      mozillaCanary,
+				"iCloudPrivateRelay": true,
+				"designatedResolver": true,
+			},
+		},
+	}
+}
+
+func TestSpecialDomainsResource_Schema(t *testing.T) {
+	r := NewSpecialDomainsResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	for attribute := range specialDomainKeys {
+		attr, exists := schemaResp.Schema.Attributes[attribute]
+		if !exists {
+			t.Errorf("Schema should have '%s' attribute", attribute)
+		} else if !attr.IsOptional() || !attr.IsComputed() {
+			t.Errorf("'%s' attribute should be optional and computed", attribute)
+		}
+	}
+}
+
+func TestSpecialDomainsResource_Metadata(t *testing.T) {
+	r := NewSpecialDomainsResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_special_domains" {
+		t.Errorf("Expected TypeName to be 'pihole_special_domains', got '%s'", resp.TypeName)
+	}
+}
+
+func TestSpecialDomainsResource_DisableMozillaCanary(t *testing.T) {
+	server := newFakePihole(specialDomainsFakeSections(true))
+	defer server.Close()
+
+	r := NewSpecialDomainsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"mozilla_canary":       tftypes.NewValue(tftypes.Bool, false),
+		"icloud_private_relay": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		"designated_resolver":  tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	dnsConfig := server.section("dns")
+	specialDomains := dnsConfig["specialDomains"].(map[string]interface{})
+	if specialDomains["mozillaCanary"] != false {
+		t.Errorf("Expected dns.specialDomains.mozillaCanary to be false, got %v", specialDomains["mozillaCanary"])
+	}
+	// Settings that are not configured must be left as they are
+	if specialDomains["iCloudPrivateRelay"] != true || specialDomains["designatedResolver"] != true {
+		t.Errorf("Expected unset special domains to be preserved, got %v", specialDomains)
+	}
+	if dnsConfig["port"] != 53.0 {
+		t.Errorf("Expected unrelated dns.port to be preserved, got %v", dnsConfig["port"])
+	}
+
+	var mozillaCanary, iCloudPrivateRelay types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("mozilla_canary"), &mozillaCanary)
+	resp.State.GetAttribute(context.Background(), path.Root("icloud_private_relay"), &iCloudPrivateRelay)
+	if mozillaCanary.ValueBool() || !iCloudPrivateRelay.ValueBool() || iCloudPrivateRelay.IsUnknown() {
+		t.Errorf("Expected state mozilla_canary=false and icloud_private_relay=true, got %s/%s", mozillaCanary, iCloudPrivateRelay)
+	}
+}
+
+func TestSpecialDomainsResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(specialDomainsFakeSections(false))
+	defer server.Close()
+
+	r := NewSpecialDomainsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Someone re-enabled the Mozilla canary domain in the web interface after the last apply
+	server.setValue("dns.specialDomains.mozillaCanary", true)
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":                   tftypes.NewValue(tftypes.String, "special_domains"),
+		"mozilla_canary":       tftypes.NewValue(tftypes.Bool, false),
+		"icloud_private_relay": tftypes.NewValue(tftypes.Bool, true),
+		"designated_resolver":  tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var mozillaCanary types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("mozilla_canary"), &mozillaCanary)
+	if !mozillaCanary.ValueBool() {
+		t.Error("Expected state to reflect Pi-hole (mozilla_canary=true)")
+	}
+}
+
+func TestSpecialDomainsResource_DeleteRestoresDefaults(t *testing.T) {
+	server := newFakePihole(specialDomainsFakeSections(false))
+	defer server.Close()
+
+	r := NewSpecialDomainsResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":                   tftypes.NewValue(tftypes.String, "special_domains"),
+		"mozilla_canary":       tftypes.NewValue(tftypes.Bool, false),
+		"icloud_private_relay": tftypes.NewValue(tftypes.Bool, true),
+		"designated_resolver":  tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	specialDomains := server.section("dns")["specialDomains"].(map[string]interface{})
+	for _, key := range specialDomainKeys {
+		if specialDomains[key] != true {
+			t.Errorf("Expected dns.specialDomains.%s to be reset to true, got %v", key, specialDomains[key])
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebInterfaceResource{}
+
+// webInterfaceThemes are the themes shipped with the Pi-hole web interface
+var webInterfaceThemes = []string{
+	"default-auto",
+	"default-light",
+	"default-dark",
+	"default-darker",
+	"high-contrast",
+	"high-contrast-dark",
+	"lcars",
+}
+
+// webInterfaceTemperatureUnits are the units Pi-hole can show the CPU temperature in: Celsius, Fahrenheit and Kelvin
+var webInterfaceTemperatureUnits = []string{"C", "F", "K"}
+
+func NewWebInterfaceResource() resource.Resource {
+	return &WebInterfaceResource{}
+}
+
+type WebInterfaceResource struct {
+	client *PiholeClient
+}
+
+type WebInterfaceResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Theme           types.String `tfsdk:"theme"`
+	BoxedLayout     types.Bool   `tfsdk:"boxed_layout"`
+	TemperatureUnit types.String `tfsdk:"temperature_unit"`
+}
+
+func (r *WebInterfaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_web_interface"
+}
+
+func (r *WebInterfaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the look of the Pi-hole web interface (`webserver.interface.theme`, `webserver.interface.boxed` " +
+			"and `webserver.api.temp.unit`). Deleting this resource resets the web interface to the `default-auto` theme with the " +
+			"boxed layout and temperatures in Celsius.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Web interface identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"theme": schema.StringAttribute{
+				MarkdownDescription: "Theme of the web interface. One of `default-auto`, `default-light`, `default-dark`, " +
+					"`default-darker`, `high-contrast`, `high-contrast-dark` or `lcars`.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(webInterfaceThemes...),
+				},
+			},
+			"boxed_layout": schema.BoolAttribute{
+				MarkdownDescription: "Whether the web interface uses the boxed layout instead of the full browser width",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"temperature_unit": schema.StringAttribute{
+				MarkdownDescription: "Unit the web interface shows the CPU temperature in. One of `C` (Celsius), `F` (Fahrenheit) or `K` (Kelvin).",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(webInterfaceTemperatureUnits...),
+				},
+			},
+		},
+	}
+}
+
+func (r *WebInterfaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WebInterfaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebInterfaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(webInterfaceValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set web interface settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read web interface settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebInterfaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebInterfaceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read web interface settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebInterfaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WebInterfaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(webInterfaceValues(data)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update web interface settings, got error: %s", err))
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read web interface settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebInterfaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	err := r.apply(map[string]interface{}{
+		"interface.theme": "default-auto",
+		"interface.boxed": true,
+		"api.temp.unit":   "C",
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset web interface settings, got error: %s", err))
+		return
+	}
+}
+
+// apply writes the given values, keyed by their path below the webserver section (e.g. interface.theme), keeping the
+// rest of the webserver section unchanged
+func (r *WebInterfaceResource) apply(values map[string]interface{}) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		if err := setNestedConfigValue(webserverConfig, strings.Split(key, "."), value); err != nil {
+			return err
+		}
+	}
+
+	return r.client.SetWebserverConfig(webserverConfig)
+}
+
+// readInto reconciles the model with the web interface settings currently active in Pi-hole
+func (r *WebInterfaceResource) readInto(data *WebInterfaceResourceModel) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	themeValue := lookupNestedConfigValue(webserverConfig, []string{"interface", "theme"})
+	theme, ok := themeValue.(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for webserver.interface.theme: %v", themeValue)
+	}
+	boxedValue := lookupNestedConfigValue(webserverConfig, []string{"interface", "boxed"})
+	boxed, ok := boxedValue.(bool)
+	if !ok {
+		return fmt.Errorf("unexpected value for webserver.interface.boxed: %v", boxedValue)
+	}
+	unitValue := lookupNestedConfigValue(webserverConfig, []string{"api", "temp", "unit"})
+	unit, ok := unitValue.(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for webserver.api.temp.unit: %v", unitValue)
+	}
+
+	data.ID = types.StringValue("web_interface")
+	data.Theme = types.StringValue(theme)
+	data.BoxedLayout = types.BoolValue(boxed)
+	data.TemperatureUnit = types.StringValue(unit)
+
+	return nil
+}
+
+// webInterfaceValues builds the webserver values from the planned model
+func webInterfaceValues(data WebInterfaceResourceModel) map[string]interface{} {
+	values := make(map[string]interface{})
+	if !data.Theme.IsNull() && !data.Theme.IsUnknown() {
+		values["interface.theme"] = data.Theme.ValueString()
+	}
+	if !data.BoxedLayout.IsNull() && !data.BoxedLayout.IsUnknown() {
+		values["interface.boxed"] = data.BoxedLayout.ValueBool()
+	}
+	if !data.TemperatureUnit.IsNull() && !data.TemperatureUnit.IsUnknown() {
+		values["api.temp.unit"] = data.TemperatureUnit.ValueString()
+	}
+	return values
+}
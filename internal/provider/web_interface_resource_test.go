@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// webInterfaceFakeSections is a webserver section with the interface settings next to unrelated ones
+func webInterfaceFakeSections(theme string, boxed bool) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"webserver": {
+			"port": "80o,443os",
+			"api": map[string]interface{}{
+				"app_sudo": false,
+				"temp":     map[string]interface{}{"limit": 60.0, "unit": "C"},
+			},
+			"interface": map[string]interface{}{"theme": theme, "boxed": boxed},
+		},
+	}
+}
+
+func TestWebInterfaceResource_Schema(t *testing.T) {
+	r := NewWebInterfaceResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	for _, name := range []string{"theme", "boxed_layout", "temperature_unit"} {
+		attr, exists := schemaResp.Schema.Attributes[name]
+		if !exists {
+			t.Errorf("Schema should have '%s' attribute", name)
+		} else if !attr.IsOptional() || !attr.IsComputed() {
+			t.Errorf("'%s' attribute should be optional and computed", name)
+		}
+	}
+}
+
+func TestWebInterfaceResource_Metadata(t *testing.T) {
+	r := NewWebInterfaceResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_web_interface" {
+		t.Errorf("Expected TypeName to be 'pihole_web_interface', got '%s'", resp.TypeName)
+	}
+}
+
+func TestWebInterfaceResource_ThemeValidation(t *testing.T) {
+	r := NewWebInterfaceResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	themeAttr := schemaResp.Schema.Attributes["theme"].(schema.StringAttribute)
+
+	testCases := []struct {
+		theme     string
+		expectErr bool
+	}{
+		{"default-auto", false},
+		{"default-dark", false},
+		{"high-contrast-dark", false},
+		{"lcars", false},
+		{"dark", true},
+		{"LCARS", true},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.theme, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("theme"),
+				ConfigValue: types.StringValue(tc.theme),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range themeAttr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For theme '%s': expected error %v, got %v", tc.theme, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestWebInterfaceResource_TemperatureUnitValidation(t *testing.T) {
+	r := NewWebInterfaceResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	unitAttr := schemaResp.Schema.Attributes["temperature_unit"].(schema.StringAttribute)
+
+	testCases := []struct {
+		unit      string
+		expectErr bool
+	}{
+		{"C", false},
+		{"F", false},
+		{"K", false},
+		{"c", true},
+		{"Celsius", true},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.unit, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("temperature_unit"),
+				ConfigValue: types.StringValue(tc.unit),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range unitAttr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For unit '%s': expected error %v, got %v", tc.unit, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestWebInterfaceResource_SetTemperatureUnit(t *testing.T) {
+	server := newFakePihole(webInterfaceFakeSections("default-auto", true))
+	defer server.Close()
+
+	r := NewWebInterfaceResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"theme":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"boxed_layout":     tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		"temperature_unit": tftypes.NewValue(tftypes.String, "F"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	temp := server.section("webserver")["api"].(map[string]interface{})["temp"].(map[string]interface{})
+	if temp["unit"] != "F" {
+		t.Errorf("Expected webserver.api.temp.unit F, got %v", temp["unit"])
+	}
+	// The temperature limit next to the unit must survive the write
+	if temp["limit"] != 60.0 {
+		t.Errorf("Expected unrelated webserver.api.temp.limit to be preserved, got %v", temp["limit"])
+	}
+
+	var unit, theme types.String
+	resp.State.GetAttribute(context.Background(), path.Root("temperature_unit"), &unit)
+	resp.State.GetAttribute(context.Background(), path.Root("theme"), &theme)
+	if unit.ValueString() != "F" || theme.ValueString() != "default-auto" {
+		t.Errorf("Expected state F/default-auto, got %s/%s", unit.ValueString(), theme.ValueString())
+	}
+}
+
+func TestWebInterfaceResource_SetTheme(t *testing.T) {
+	server := newFakePihole(webInterfaceFakeSections("default-auto", true))
+	defer server.Close()
+
+	r := NewWebInterfaceResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"theme":        tftypes.NewValue(tftypes.String, "default-darker"),
+		"boxed_layout": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	webserverConfig := server.section("webserver")
+	iface := webserverConfig["interface"].(map[string]interface{})
+	if iface["theme"] != "default-darker" || iface["boxed"] != false {
+		t.Errorf("Expected interface settings default-darker/false, got %v/%v", iface["theme"], iface["boxed"])
+	}
+	// Unrelated webserver settings must survive the write of the whole section
+	if webserverConfig["port"] != "80o,443os" {
+		t.Errorf("Expected unrelated webserver.port to be preserved, got %v", webserverConfig["port"])
+	}
+
+	var theme types.String
+	var boxed types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("theme"), &theme)
+	resp.State.GetAttribute(context.Background(), path.Root("boxed_layout"), &boxed)
+	if theme.ValueString() != "default-darker" || boxed.ValueBool() {
+		t.Errorf("Expected state default-darker/false, got %s/%t", theme.ValueString(), boxed.ValueBool())
+	}
+}
+
+func TestWebInterfaceResource_CreateLeavesUnsetAttributes(t *testing.T) {
+	server := newFakePihole(webInterfaceFakeSections("lcars", false))
+	defer server.Close()
+
+	r := NewWebInterfaceResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"theme":        tftypes.NewValue(tftypes.String, "default-light"),
+		"boxed_layout": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var boxed types.Bool
+	resp.State.GetAttribute(context.Background(), path.Root("boxed_layout"), &boxed)
+	if boxed.IsUnknown() || boxed.ValueBool() {
+		t.Errorf("Expected the unset boxed_layout to be read back as false, got %s", boxed)
+	}
+}
+
+func TestWebInterfaceResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(webInterfaceFakeSections("default-dark", true))
+	defer server.Close()
+
+	r := NewWebInterfaceResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Someone changed the theme in the web interface after the last apply
+	server.setValue("webserver.interface.theme", "high-contrast")
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "web_interface"),
+		"theme":        tftypes.NewValue(tftypes.String, "default-dark"),
+		"boxed_layout": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var theme types.String
+	resp.State.GetAttribute(context.Background(), path.Root("theme"), &theme)
+	if theme.ValueString() != "high-contrast" {
+		t.Errorf("Expected state to reflect Pi-hole (high-contrast), got %s", theme.ValueString())
+	}
+}
+
+func TestWebInterfaceResource_DeleteResetsToDefaults(t *testing.T) {
+	server := newFakePihole(webInterfaceFakeSections("lcars", false))
+	defer server.Close()
+
+	r := NewWebInterfaceResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "web_interface"),
+		"theme":        tftypes.NewValue(tftypes.String, "lcars"),
+		"boxed_layout": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	iface := server.section("webserver")["interface"].(map[string]interface{})
+	if iface["theme"] != "default-auto" || iface["boxed"] != true {
+		t.Errorf("Expected web interface to be reset to default-auto/true, got %v/%v", iface["theme"], iface["boxed"])
+	}
+	temp := server.section("webserver")["api"].(map[string]interface{})["temp"].(map[string]interface{})
+	if temp["unit"] != "C" {
+		t.Errorf("Expected temperature unit to be reset to C, got %v", temp["unit"])
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWebPasswordResource_Schema(t *testing.T) {
+	r := NewWebPasswordResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	attr, exists := schemaResp.Schema.Attributes["password"]
+	if !exists {
+		t.Fatal("Schema should have 'password' attribute")
+	}
+	if !attr.IsRequired() || !attr.IsSensitive() {
+		t.Error("'password' attribute should be required and sensitive")
+	}
+}
+
+func TestWebPasswordResource_SetPassword(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api": map[string]interface{}{"password": "********", "app_sudo": false},
+		},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	r := NewWebPasswordResource()
+	testConfigureResource(t, r, client)
+
+	authsBefore := server.requestCount("POST /api/auth")
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"password": tftypes.NewValue(tftypes.String, "new-secret"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	// The plaintext is sent, Pi-hole hashes it on its side
+	api := server.section("webserver")["api"].(map[string]interface{})
+	if api["password"] != "new-secret" {
+		t.Errorf("Expected webserver.api.password to be set to new-secret, got %v", api["password"])
+	}
+	if api["app_sudo"] != false {
+		t.Errorf("Expected unrelated webserver.api.app_sudo to be preserved, got %v", api["app_sudo"])
+	}
+
+	// Pi-hole ends all sessions when the password changes, so the client must log in again with the new one
+	if client.Password != "new-secret" {
+		t.Errorf("Expected the client to use the new password, got %q", client.Password)
+	}
+	if auths := server.requestCount("POST /api/auth") - authsBefore; auths != 1 {
+		t.Errorf("Expected 1 authentication after the change, got %d", auths)
+	}
+}
+
+func TestWebPasswordResource_DeleteKeepsPassword(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api": map[string]interface{}{"password": "********"},
+		},
+	})
+	defer server.Close()
+
+	r := NewWebPasswordResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "web_password"),
+		"password": tftypes.NewValue(tftypes.String, "new-secret"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if writes := server.writeRequests(); len(writes) != 0 {
+		t.Errorf("Expected Delete not to change the password, got writes %v", writes)
+	}
+}
+
+// Run with -race: the session is replaced while other resources send requests through the shared client
+func TestPiholeClient_SetWebPasswordWhileRequestsRun(t *testing.T) {
+	clearClientCache()
+	defer clearClientCache()
+
+	server := newFakePihole(map[string]map[string]interface{}{
+		"dns":       {"hosts": []string{"192.168.1.10 nas.example.com"}},
+		"webserver": {"api": map[string]interface{}{"password": "********"}},
+	})
+	defer server.Close()
+
+	config := ClientConfig{MaxConnections: 4}
+	client, err := getOrCreateClient(context.Background(), server.URL, "old-secret", config)
+	if err != nil {
+		t.Fatalf("Failed to create Pi-hole client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				if _, err := client.GetDNSRecords(); err != nil {
+					t.Errorf("GetDNSRecords failed: %v", err)
+				}
+			}
+		}()
+	}
+
+	if err := client.SetWebPassword("new-secret"); err != nil {
+		t.Errorf("SetWebPassword failed: %v", err)
+	}
+	wg.Wait()
+
+	// The client moves to the cache key of the new password, the old one no longer finds it
+	if _, exists := cachedClient(clientCacheKey(server.URL, "old-secret", config)); exists {
+		t.Error("Expected the cache entry of the old password to be removed")
+	}
+	reused, err := getOrCreateClient(context.Background(), server.URL, "new-secret", config)
+	if err != nil {
+		t.Fatalf("Failed to get Pi-hole client: %v", err)
+	}
+	if reused != client {
+		t.Error("Expected a provider configured with the new password to reuse the client")
+	}
+}
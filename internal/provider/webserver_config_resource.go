@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebserverConfigResource{}
+var _ resource.ResourceWithImportState = &WebserverConfigResource{}
+
+func NewWebserverConfigResource() resource.Resource {
+	return &WebserverConfigResource{}
+}
+
+type WebserverConfigResource struct {
+	client *PiholeClient
+}
+
+type WebserverConfigResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Settings       types.Map    `tfsdk:"settings"`
+	RequestDelayMs types.Int64  `tfsdk:"request_delay_ms"`
+}
+
+func (r *WebserverConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webserver_config"
+}
+
+func (r *WebserverConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages multiple settings of the Pi-hole webserver configuration section in one resource. " +
+			"Only the settings listed in `settings` are managed. Importing this resource (`terraform import ... webserver`) " +
+			"reads the whole webserver section, which makes migrating an existing Pi-hole a single command. " +
+			"**Important**: Configuration changes require admin password, not application password.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier (always `webserver`)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Webserver settings keyed by their full dotted configuration key " +
+					"(e.g., `webserver.api.app_sudo`). Values use the same string representation as `pihole_config`: " +
+					"booleans are `true`/`false`, numbers are plain digits and arrays are JSON encoded.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^webserver\.[A-Za-z0-9_.]+$`),
+						"must be a dotted configuration key within the webserver section",
+					)),
+				},
+			},
+			"request_delay_ms": schema.Int64Attribute{
+				MarkdownDescription: "Delay in milliseconds before each API call of this resource, overriding the provider's " +
+					"`request_delay_ms`. Defaults to the provider setting.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *WebserverConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WebserverConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebserverConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := make(map[string]string)
+	resp.Diagnostics.Append(data.Settings.ElementsAs(ctx, &settings, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applySettings(settings, requestDelayOptions(data.RequestDelayMs)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Pi-hole Webserver Configuration",
+			fmt.Sprintf("Could not apply webserver settings: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("webserver")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebserverConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webserverConfig, err := r.client.GetWebserverConfig(requestDelayOptions(data.RequestDelayMs)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Pi-hole Webserver Configuration",
+			fmt.Sprintf("Could not read webserver configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	current := flattenConfigValues("webserver", webserverConfig)
+
+	// After an import no settings are known yet, so the whole section is adopted.
+	// Otherwise only the managed settings are refreshed.
+	settings := current
+	if !data.Settings.IsNull() {
+		managed := make(map[string]string)
+		resp.Diagnostics.Append(data.Settings.ElementsAs(ctx, &managed, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		settings = make(map[string]string, len(managed))
+		for key := range managed {
+			if value, exists := current[key]; exists {
+				settings[key] = value
+			}
+		}
+	}
+
+	settingsValue, diags := types.MapValueFrom(ctx, types.StringType, settings)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("webserver")
+	data.Settings = settingsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WebserverConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := make(map[string]string)
+	resp.Diagnostics.Append(data.Settings.ElementsAs(ctx, &settings, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applySettings(settings, requestDelayOptions(data.RequestDelayMs)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Pi-hole Webserver Configuration",
+			fmt.Sprintf("Could not apply webserver settings: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("webserver")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The webserver section has no safe bulk default, so settings are left as they are
+	// and the resource is only removed from state.
+}
+
+func (r *WebserverConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "webserver" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The webserver configuration can only be imported with the ID 'webserver', got: %s", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// applySettings writes all settings that differ from the current webserver configuration in a single request
+func (r *WebserverConfigResource) applySettings(settings map[string]string, opts []RequestOption) error {
+	webserverConfig, err := r.client.GetWebserverConfig(opts...)
+	if err != nil {
+		return err
+	}
+
+	current := flattenConfigValues("webserver", webserverConfig)
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changed := false
+	for _, key := range keys {
+		value := settings[key]
+		if currentValue, exists := current[key]; exists && currentValue == value {
+			continue
+		}
+
+		keyParts := strings.Split(strings.TrimPrefix(key, "webserver."), ".")
+		configValue, err := configValueFromString(value, lookupNestedConfigValue(webserverConfig, keyParts))
+		if err != nil {
+			return fmt.Errorf("invalid value for '%s': %w", key, err)
+		}
+
+		if err := setNestedConfigValue(webserverConfig, keyParts, configValue); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return r.client.SetWebserverConfig(webserverConfig, opts...)
+}
+
+// flattenConfigValues flattens a nested configuration section into dotted keys with string values
+func flattenConfigValues(prefix string, values map[string]interface{}) map[string]string {
+	flattened := make(map[string]string)
+	for key, value := range values {
+		fullKey := prefix + "." + key
+		if nested, ok := value.(map[string]interface{}); ok {
+			for nestedKey, nestedValue := range flattenConfigValues(fullKey, nested) {
+				flattened[nestedKey] = nestedValue
+			}
+			continue
+		}
+		flattened[fullKey] = configValueToString(value)
+	}
+	return flattened
+}
+
+// lookupNestedConfigValue returns the value at the given path or nil if it does not exist
+func lookupNestedConfigValue(values map[string]interface{}, keyParts []string) interface{} {
+	var current interface{} = values
+	for _, part := range keyParts {
+		nested, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = nested[part]
+	}
+	return current
+}
+
+// configValueFromString converts a string value back to the type of the current configuration value.
+// Values Pi-hole stores as strings stay strings, even if they look like booleans or numbers.
+func configValueFromString(value string, current interface{}) (interface{}, error) {
+	switch current.(type) {
+	case bool:
+		return strconv.ParseBool(strings.ToLower(strings.TrimSpace(value)))
+	case float64:
+		return strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case []interface{}:
+		var items []interface{}
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return nil, fmt.Errorf("expected a JSON array: %w", err)
+		}
+		return items, nil
+	case string:
+		return value, nil
+	}
+
+	// Unknown keys follow the same conversion as pihole_config
+	if strings.ToLower(value) == "true" {
+		return true, nil
+	} else if strings.ToLower(value) == "false" {
+		return false, nil
+	}
+	return value, nil
+}
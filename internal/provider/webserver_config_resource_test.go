@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPiholeWebserverConfig_import(t *testing.T) {
+	testAccPreCheck(t)
+
+	// The configuration must list the whole live webserver section for the plan to be clean after the import
+	client, err := getOrCreateClient(context.Background(), os.Getenv("PIHOLE_URL"), os.Getenv("PIHOLE_PASSWORD"), ClientConfig{
+		MaxConnections: 1,
+		RequestDelayMs: 300,
+		RetryAttempts:  3,
+		RetryBackoffMs: 500,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	webserverConfig, err := client.GetWebserverConfig()
+	if err != nil {
+		t.Fatalf("Failed to get webserver configuration: %v", err)
+	}
+	settings := flattenConfigValues("webserver", webserverConfig)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Import the live webserver configuration
+			{
+				Config:             testAccPiholeWebserverConfigConfig(settings),
+				ResourceName:       "pihole_webserver_config.test",
+				ImportState:        true,
+				ImportStateId:      "webserver",
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if got := states[0].Attributes["settings.%"]; got != fmt.Sprintf("%d", len(settings)) {
+						return fmt.Errorf("expected %d imported settings, got %s", len(settings), got)
+					}
+					if got := states[0].Attributes["settings.webserver.api.app_sudo"]; got != settings["webserver.api.app_sudo"] {
+						return fmt.Errorf("expected imported webserver.api.app_sudo '%s', got '%s'", settings["webserver.api.app_sudo"], got)
+					}
+					return nil
+				},
+			},
+			// The imported state matches the configuration
+			{
+				Config:   testAccPiholeWebserverConfigConfig(settings),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPiholeWebserverConfigConfig(settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var entries strings.Builder
+	for _, key := range keys {
+		value := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(settings[key])
+		fmt.Fprintf(&entries, "    %q = %q\n", key, value)
+	}
+
+	return fmt.Sprintf(`
+%s
+
+resource "pihole_webserver_config" "test" {
+  settings = {
+%s  }
+}
+`, testAccPiholeProviderBlock(), entries.String())
+}
+
+// Unit tests for webserver config resource
+func TestWebserverConfigResource_Schema(t *testing.T) {
+	r := NewWebserverConfigResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema has errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	settingsAttr, exists := schemaResp.Schema.Attributes["settings"]
+	if !exists {
+		t.Error("Schema should have 'settings' attribute")
+	} else if !settingsAttr.IsRequired() {
+		t.Error("'settings' attribute should be required")
+	}
+
+	if _, exists := schemaResp.Schema.Attributes["id"]; !exists {
+		t.Error("Schema should have 'id' attribute")
+	}
+}
+
+func TestWebserverConfigResource_Metadata(t *testing.T) {
+	r := NewWebserverConfigResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_webserver_config" {
+		t.Errorf("Expected TypeName to be 'pihole_webserver_config', got '%s'", resp.TypeName)
+	}
+}
+
+func TestFlattenConfigValues(t *testing.T) {
+	flattened := flattenConfigValues("webserver", map[string]interface{}{
+		"port": "80o,443os",
+		"api": map[string]interface{}{
+			"app_sudo":     true,
+			"max_sessions": 16.0,
+			"acl":          []interface{}{"192.168.1.0/24"},
+		},
+		"session": map[string]interface{}{"timeout": 1800.0},
+	})
+
+	expected := map[string]string{
+		"webserver.port":             "80o,443os",
+		"webserver.api.app_sudo":     "true",
+		"webserver.api.max_sessions": "16",
+		"webserver.api.acl":          `["192.168.1.0/24"]`,
+		"webserver.session.timeout":  "1800",
+	}
+
+	if len(flattened) != len(expected) {
+		t.Errorf("Expected %d flattened keys, got %d: %v", len(expected), len(flattened), flattened)
+	}
+	for key, value := range expected {
+		if flattened[key] != value {
+			t.Errorf("For key '%s': expected '%s', got '%s'", key, value, flattened[key])
+		}
+	}
+}
+
+func TestConfigValueFromString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		current  interface{}
+		expected interface{}
+	}{
+		{"bool", "True", false, true},
+		{"number", "1800", 300.0, 1800.0},
+		{"string", "true", "default-dark", "true"},
+		{"unknown bool", "false", nil, false},
+		{"unknown string", "auto", nil, "auto"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := configValueFromString(tc.value, tc.current)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v (%T), got %v (%T)", tc.expected, tc.expected, result, result)
+			}
+		})
+	}
+
+	items, err := configValueFromString(`["a","b"]`, []interface{}{})
+	if err != nil {
+		t.Fatalf("Unexpected error for array: %v", err)
+	}
+	if list, ok := items.([]interface{}); !ok || len(list) != 2 {
+		t.Errorf("Expected a two element array, got %v", items)
+	}
+
+	if _, err := configValueFromString("many", 16.0); err == nil {
+		t.Error("Expected an error for a non-numeric value of a numeric setting")
+	}
+}
+
+func TestWebserverConfigResource_ImportReadsWholeSection(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api":     map[string]interface{}{"app_sudo": false, "max_sessions": 16.0},
+			"session": map[string]interface{}{"timeout": 1800.0},
+		},
+	})
+	defer server.Close()
+
+	r := NewWebserverConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	schemaResp, state := testResourceObject(t, r, nil)
+	importResp := &fwresource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: "webserver"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState has errors: %v", importResp.Diagnostics.Errors())
+	}
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "webserver"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var settings map[string]string
+	resp.State.GetAttribute(context.Background(), path.Root("settings"), &settings)
+
+	expected := map[string]string{
+		"webserver.api.app_sudo":     "false",
+		"webserver.api.max_sessions": "16",
+		"webserver.session.timeout":  "1800",
+	}
+	if len(settings) != len(expected) {
+		t.Errorf("Expected %d imported settings, got %v", len(expected), settings)
+	}
+	for key, value := range expected {
+		if settings[key] != value {
+			t.Errorf("For key '%s': expected '%s', got '%s'", key, value, settings[key])
+		}
+	}
+}
+
+func TestWebserverConfigResource_ImportRejectsOtherIDs(t *testing.T) {
+	r := NewWebserverConfigResource()
+
+	schemaResp, state := testResourceObject(t, r, nil)
+	resp := &fwresource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: "dns"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected import with an ID other than 'webserver' to fail")
+	}
+}
+
+func TestWebserverConfigResource_ReadOnlyRefreshesManagedSettings(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api":     map[string]interface{}{"app_sudo": true, "max_sessions": 16.0},
+			"session": map[string]interface{}{"timeout": 1800.0},
+		},
+	})
+	defer server.Close()
+
+	r := NewWebserverConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "webserver"),
+		"settings": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"webserver.api.app_sudo": tftypes.NewValue(tftypes.String, "false"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var settings map[string]string
+	resp.State.GetAttribute(context.Background(), path.Root("settings"), &settings)
+
+	if len(settings) != 1 || settings["webserver.api.app_sudo"] != "true" {
+		t.Errorf("Expected only the managed setting with its live value, got %v", settings)
+	}
+}
+
+func TestWebserverConfigResource_UpdateConvertsTypes(t *testing.T) {
+	server := newFakePihole(map[string]map[string]interface{}{
+		"webserver": {
+			"api":     map[string]interface{}{"app_sudo": false, "max_sessions": 16.0},
+			"session": map[string]interface{}{"timeout": 1800.0},
+		},
+	})
+	defer server.Close()
+
+	r := NewWebserverConfigResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	settingsType := tftypes.Map{ElementType: tftypes.String}
+	resp := testResourceUpdate(t, r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "webserver"),
+		"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+			"webserver.api.app_sudo": tftypes.NewValue(tftypes.String, "false"),
+		}),
+	}, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "webserver"),
+		"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+			"webserver.api.app_sudo":    tftypes.NewValue(tftypes.String, "true"),
+			"webserver.session.timeout": tftypes.NewValue(tftypes.String, "3600"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	webserverConfig := server.section("webserver")
+	api := webserverConfig["api"].(map[string]interface{})
+	session := webserverConfig["session"].(map[string]interface{})
+	if api["app_sudo"] != true {
+		t.Errorf("Expected webserver.api.app_sudo to be the boolean true, got %v (%T)", api["app_sudo"], api["app_sudo"])
+	}
+	if session["timeout"] != 3600.0 {
+		t.Errorf("Expected webserver.session.timeout to be the number 3600, got %v (%T)", session["timeout"], session["timeout"])
+	}
+	// Unmanaged settings are sent back unchanged
+	if api["max_sessions"] != 16.0 {
+		t.Errorf("Expected unmanaged webserver.api.max_sessions to be preserved, got %v", api["max_sessions"])
+	}
+
+	var id types.String
+	resp.State.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "webserver" {
+		t.Errorf("Expected id 'webserver', got '%s'", id.ValueString())
+	}
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebserverPortResource{}
+var _ resource.ResourceWithModifyPlan = &WebserverPortResource{}
+
+// defaultWebserverPort is the value of webserver.port in a fresh Pi-hole installation
+const defaultWebserverPort = "80o,443os,[::]:80o,[::]:443os"
+
+// webserverPortPattern matches a comma separated list of ports, each with an optional bind address and the
+// o (optional), s (TLS) and r (redirect to TLS) suffixes, e.g. 80o,443os,[::]:80o
+var webserverPortPattern = regexp.MustCompile(
+	`^(` + webserverPortEntry + `)(,` + webserverPortEntry + `)*$`,
+)
+
+const webserverPortEntry = `(\[[0-9A-Fa-f:.]+\]:|[0-9]{1,3}(\.[0-9]{1,3}){3}:)?` +
+	`([1-9][0-9]{0,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])[osr]{0,3}`
+
+func NewWebserverPortResource() resource.Resource {
+	return &WebserverPortResource{}
+}
+
+type WebserverPortResource struct {
+	client *PiholeClient
+}
+
+type WebserverPortResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Port types.String `tfsdk:"port"`
+}
+
+func (r *WebserverPortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webserver_port"
+}
+
+func (r *WebserverPortResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the ports the Pi-hole web interface and API listen on (`webserver.port`). " +
+			"**Warning**: The provider talks to the API through these ports. Once a change is applied, the provider " +
+			"can no longer reach Pi-hole under its configured `url` until that is updated as well, so change the " +
+			"port in an apply of its own. Deleting this resource resets Pi-hole to the ports `" + defaultWebserverPort + "`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Webserver port identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port": schema.StringAttribute{
+				MarkdownDescription: "Comma separated list of ports, e.g. `80o,443os`. Each port may be prefixed with a " +
+					"bind address such as `[::]:` or `127.0.0.1:` and suffixed with `o` (optional, skipped if the port is " +
+					"in use), `s` (TLS) and `r` (redirect to a TLS port).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(webserverPortPattern,
+						"must be a comma separated list of ports like 80o,443os, each optionally prefixed with a bind address"),
+				},
+			},
+		},
+	}
+}
+
+func (r *WebserverPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to warn about when destroying, or when the port is unchanged
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WebserverPortResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Port.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state WebserverPortResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.Port.Equal(plan.Port) {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("port"),
+		"Webserver Port Change",
+		fmt.Sprintf("Pi-hole will listen on '%s' after this apply. Resources applied later in the same run may fail "+
+			"if the provider url no longer matches, so apply the port change on its own and update the url afterwards.",
+			plan.Port.ValueString()),
+	)
+}
+
+func (r *WebserverPortResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PiholeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WebserverPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebserverPortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(data.Port.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set webserver port, got error: %s", err))
+		return
+	}
+
+	// Pi-hole may no longer answer on the old port, so the planned value is stored without reading it back
+	data.ID = types.StringValue("webserver_port")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebserverPortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readInto(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webserver port, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WebserverPortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(data.Port.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update webserver port, got error: %s", err))
+		return
+	}
+
+	// Pi-hole may no longer answer on the old port, so the planned value is stored without reading it back
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebserverPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to the Pi-hole defaults rather than leaving the last managed values in place
+	if err := r.apply(defaultWebserverPort); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset webserver port, got error: %s", err))
+		return
+	}
+}
+
+// apply writes webserver.port, keeping the rest of the webserver section unchanged
+func (r *WebserverPortResource) apply(port string) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	webserverConfig["port"] = port
+
+	return r.client.SetWebserverConfig(webserverConfig)
+}
+
+// readInto reconciles the model with the webserver port currently active in Pi-hole
+func (r *WebserverPortResource) readInto(data *WebserverPortResourceModel) error {
+	webserverConfig, err := r.client.GetWebserverConfig()
+	if err != nil {
+		return err
+	}
+
+	port, ok := webserverConfig["port"].(string)
+	if !ok {
+		return fmt.Errorf("unexpected value for webserver.port: %v", webserverConfig["port"])
+	}
+
+	data.ID = types.StringValue("webserver_port")
+	data.Port = types.StringValue(port)
+
+	return nil
+}
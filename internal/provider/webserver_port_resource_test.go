@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// webserverPortFakeSections is a webserver section with the port next to unrelated settings
+func webserverPortFakeSections(port string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"webserver": {
+			"port":      port,
+			"api":       map[string]interface{}{"app_sudo": false},
+			"interface": map[string]interface{}{"theme": "lcars", "boxed": true},
+		},
+	}
+}
+
+func TestWebserverPortResource_Metadata(t *testing.T) {
+	r := NewWebserverPortResource()
+
+	resp := &fwresource.MetadataResponse{}
+	r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_webserver_port" {
+		t.Errorf("Expected TypeName to be 'pihole_webserver_port', got '%s'", resp.TypeName)
+	}
+}
+
+func TestWebserverPortResource_PortValidation(t *testing.T) {
+	r := NewWebserverPortResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	portAttr := schemaResp.Schema.Attributes["port"].(schema.StringAttribute)
+
+	testCases := []struct {
+		port      string
+		expectErr bool
+	}{
+		{"80", false},
+		{"8080o", false},
+		{"80o,443os", false},
+		{"80o,443os,[::]:80o,[::]:443os", false},
+		{"127.0.0.1:8080", false},
+		{"80r,443s", false},
+		{"", true},
+		{"http", true},
+		{"80,", true},
+		{"80 o", true},
+		{"0", true},
+		{"65536", true},
+		{"443x", true},
+		{"80o;443os", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.port, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("port"),
+				ConfigValue: types.StringValue(tc.port),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range portAttr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("For port '%s': expected error %v, got %v", tc.port, tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestWebserverPortResource_SetPort(t *testing.T) {
+	server := newFakePihole(webserverPortFakeSections(defaultWebserverPort))
+	defer server.Close()
+
+	r := NewWebserverPortResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceCreate(t, r, map[string]tftypes.Value{
+		"port": tftypes.NewValue(tftypes.String, "8080o,8443os"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	webserverConfig := server.section("webserver")
+	if webserverConfig["port"] != "8080o,8443os" {
+		t.Errorf("Expected webserver.port to be 8080o,8443os, got %v", webserverConfig["port"])
+	}
+	// Unrelated webserver settings must survive the write of the whole section
+	if iface := webserverConfig["interface"].(map[string]interface{}); iface["theme"] != "lcars" {
+		t.Errorf("Expected unrelated webserver.interface.theme to be preserved, got %v", iface["theme"])
+	}
+
+	var port types.String
+	resp.State.GetAttribute(context.Background(), path.Root("port"), &port)
+	if port.ValueString() != "8080o,8443os" {
+		t.Errorf("Expected state port 8080o,8443os, got %s", port.ValueString())
+	}
+}
+
+func TestWebserverPortResource_ModifyPlanWarnsOnChange(t *testing.T) {
+	r := NewWebserverPortResource()
+
+	state := map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "webserver_port"),
+		"port": tftypes.NewValue(tftypes.String, "80o,443os"),
+	}
+
+	unchanged := testResourceModifyPlan(t, r, state, state)
+	if len(unchanged.Diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics for an unchanged port, got %v", unchanged.Diagnostics)
+	}
+
+	changed := testResourceModifyPlan(t, r, state, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "webserver_port"),
+		"port": tftypes.NewValue(tftypes.String, "8080o"),
+	})
+	if changed.Diagnostics.HasError() || changed.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Expected a single warning for a port change, got %v", changed.Diagnostics)
+	}
+}
+
+func TestWebserverPortResource_ReadReconcilesDrift(t *testing.T) {
+	server := newFakePihole(webserverPortFakeSections("80o,443os"))
+	defer server.Close()
+
+	r := NewWebserverPortResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	// Someone changed the port in the web interface after the last apply
+	server.setValue("webserver.port", "8080o")
+
+	resp := testResourceRead(t, r, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "webserver_port"),
+		"port": tftypes.NewValue(tftypes.String, "80o,443os"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var port types.String
+	resp.State.GetAttribute(context.Background(), path.Root("port"), &port)
+	if port.ValueString() != "8080o" {
+		t.Errorf("Expected state to reflect Pi-hole (8080o), got %s", port.ValueString())
+	}
+}
+
+func TestWebserverPortResource_DeleteResetsToDefault(t *testing.T) {
+	server := newFakePihole(webserverPortFakeSections("8080o"))
+	defer server.Close()
+
+	r := NewWebserverPortResource()
+	testConfigureResource(t, r, newTestClient(t, server.URL))
+
+	resp := testResourceDelete(t, r, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "webserver_port"),
+		"port": tftypes.NewValue(tftypes.String, "8080o"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	if port := server.section("webserver")["port"]; port != defaultWebserverPort {
+		t.Errorf("Expected webserver.port to be reset to %s, got %v", defaultWebserverPort, port)
+	}
+}
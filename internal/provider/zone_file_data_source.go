@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ZoneFileDataSource{}
+
+// Defaults of the record TTL and the SOA timers when the configuration doesn't set them
+const (
+	defaultZoneTTL     = 3600
+	defaultZoneRefresh = 86400
+	defaultZoneRetry   = 7200
+	defaultZoneExpire  = 3600000
+	defaultZoneMinimum = 3600
+)
+
+func NewZoneFileDataSource() datasource.DataSource {
+	return &ZoneFileDataSource{}
+}
+
+type ZoneFileDataSource struct {
+	client *PiholeClient
+}
+
+type ZoneFileDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Origin     types.String `tfsdk:"origin"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	PrimaryNS  types.String `tfsdk:"primary_ns"`
+	AdminEmail types.String `tfsdk:"admin_email"`
+	Serial     types.Int64  `tfsdk:"serial"`
+	Refresh    types.Int64  `tfsdk:"refresh"`
+	Retry      types.Int64  `tfsdk:"retry"`
+	Expire     types.Int64  `tfsdk:"expire"`
+	Minimum    types.Int64  `tfsdk:"minimum"`
+	Content    types.String `tfsdk:"content"`
+}
+
+// zoneFileSOA holds the values of the SOA record of a rendered zone
+type zoneFileSOA struct {
+	PrimaryNS  string
+	AdminEmail string
+	Serial     int64
+	Refresh    int64
+	Retry      int64
+	Expire     int64
+	Minimum    int64
+}
+
+func (d *ZoneFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_file"
+}
+
+func (d *ZoneFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	timer := func(description string, defaultValue int) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("%s in seconds (default: %d)", description, defaultValue),
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the local DNS A and CNAME records of a zone in BIND zone file syntax, " +
+			"e.g. to hand them to an authoritative DNS server",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier, the origin of the zone",
+				Computed:            true,
+			},
+			"origin": schema.StringAttribute{
+				MarkdownDescription: "Domain of the zone (e.g. `homelab.local`). Only records of this domain and its subdomains are rendered.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ttl":     timer("Default TTL of the records", defaultZoneTTL),
+			"refresh": timer("SOA refresh interval", defaultZoneRefresh),
+			"retry":   timer("SOA retry interval", defaultZoneRetry),
+			"expire":  timer("SOA expire time", defaultZoneExpire),
+			"minimum": timer("SOA negative caching TTL", defaultZoneMinimum),
+			"primary_ns": schema.StringAttribute{
+				MarkdownDescription: "Primary name server of the zone (default: `ns1.<origin>`)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"admin_email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the zone administrator, either as `user@example.com` or in zone file " +
+					"notation (default: `hostmaster.<origin>`)",
+				Optional: true,
+				Computed: true,
+			},
+			"serial": schema.Int64Attribute{
+				MarkdownDescription: "SOA serial number (default: 1)",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 4294967295),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The rendered zone file",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*PiholeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *PiholeClient, got something else",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneFileDataSourceModel
+
+	// Read configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	origin := strings.ToLower(strings.TrimSuffix(data.Origin.ValueString(), "."))
+
+	if data.TTL.IsNull() {
+		data.TTL = types.Int64Value(defaultZoneTTL)
+	}
+	if data.PrimaryNS.IsNull() {
+		data.PrimaryNS = types.StringValue("ns1." + origin)
+	}
+	if data.AdminEmail.IsNull() {
+		data.AdminEmail = types.StringValue("hostmaster." + origin)
+	}
+	if data.Serial.IsNull() {
+		data.Serial = types.Int64Value(1)
+	}
+	if data.Refresh.IsNull() {
+		data.Refresh = types.Int64Value(defaultZoneRefresh)
+	}
+	if data.Retry.IsNull() {
+		data.Retry = types.Int64Value(defaultZoneRetry)
+	}
+	if data.Expire.IsNull() {
+		data.Expire = types.Int64Value(defaultZoneExpire)
+	}
+	if data.Minimum.IsNull() {
+		data.Minimum = types.Int64Value(defaultZoneMinimum)
+	}
+
+	snapshot, err := d.client.Snapshot(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read DNS and CNAME records: "+err.Error())
+		return
+	}
+	records, cnameRecords := snapshot.DNSRecords, snapshot.CNAMERecords
+
+	soa := zoneFileSOA{
+		PrimaryNS:  data.PrimaryNS.ValueString(),
+		AdminEmail: data.AdminEmail.ValueString(),
+		Serial:     data.Serial.ValueInt64(),
+		Refresh:    data.Refresh.ValueInt64(),
+		Retry:      data.Retry.ValueInt64(),
+		Expire:     data.Expire.ValueInt64(),
+		Minimum:    data.Minimum.ValueInt64(),
+	}
+
+	data.ID = types.StringValue(origin)
+	data.Content = types.StringValue(renderZoneFile(origin, data.TTL.ValueInt64(), soa, records, cnameRecords))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// zoneFileEntry is a single resource record of a rendered zone
+type zoneFileEntry struct {
+	name   string
+	rrType string
+	data   string
+}
+
+// renderZoneFile renders the records of the origin's zone in BIND zone file syntax. Names inside the
+// zone are written relative to the origin, records of other domains are left out.
+func renderZoneFile(origin string, ttl int64, soa zoneFileSOA, records []DNSRecord, cnameRecords []CNAMERecord) string {
+	var entries []zoneFileEntry
+	for _, record := range records {
+		name, ok := zoneRelativeName(record.Domain, origin)
+		if !ok {
+			continue
+		}
+
+		rrType := "A"
+		if ip := net.ParseIP(record.IP); ip != nil && ip.To4() == nil {
+			rrType = "AAAA"
+		}
+		entries = append(entries, zoneFileEntry{name: name, rrType: rrType, data: record.IP})
+	}
+	for _, record := range cnameRecords {
+		name, ok := zoneRelativeName(record.Domain, origin)
+		// A CNAME can't coexist with the SOA and NS records at the apex of the zone
+		if !ok || name == "@" {
+			continue
+		}
+
+		target, inZone := zoneRelativeName(record.Target, origin)
+		if !inZone || target == "@" {
+			target = zoneAbsoluteName(record.Target)
+		}
+		entries = append(entries, zoneFileEntry{name: name, rrType: "CNAME", data: target})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].name != entries[j].name {
+			return entries[i].name < entries[j].name
+		}
+		return entries[i].rrType < entries[j].rrType
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", zoneAbsoluteName(origin))
+	fmt.Fprintf(&b, "$TTL %d\n", ttl)
+	fmt.Fprintf(&b, "@\tIN\tSOA\t%s %s (\n", zoneAbsoluteName(soa.PrimaryNS), zoneAdminMailbox(soa.AdminEmail))
+	fmt.Fprintf(&b, "\t\t%d\t; serial\n", soa.Serial)
+	fmt.Fprintf(&b, "\t\t%d\t; refresh\n", soa.Refresh)
+	fmt.Fprintf(&b, "\t\t%d\t; retry\n", soa.Retry)
+	fmt.Fprintf(&b, "\t\t%d\t; expire\n", soa.Expire)
+	fmt.Fprintf(&b, "\t\t%d )\t; minimum\n", soa.Minimum)
+	fmt.Fprintf(&b, "@\tIN\tNS\t%s\n", zoneAbsoluteName(soa.PrimaryNS))
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "%s\tIN\t%s\t%s\n", entry.name, entry.rrType, entry.data)
+	}
+
+	return b.String()
+}
+
+// zoneRelativeName returns the name of the domain relative to the origin, "@" for the origin itself.
+// It reports false for domains outside of the zone.
+func zoneRelativeName(domain, origin string) (string, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == origin {
+		return "@", true
+	}
+	if !strings.HasSuffix(domain, "."+origin) {
+		return "", false
+	}
+	return escapeZoneName(strings.TrimSuffix(domain, "."+origin)), true
+}
+
+// zoneAbsoluteName returns the domain as a fully qualified name with the trailing dot
+func zoneAbsoluteName(domain string) string {
+	return escapeZoneName(strings.TrimSuffix(domain, ".")) + "."
+}
+
+// zoneAdminMailbox converts an email address to the mailbox notation of the SOA record, where the
+// '@' becomes a dot and dots in the local part are escaped
+func zoneAdminMailbox(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return zoneAbsoluteName(email)
+	}
+	return strings.ReplaceAll(escapeZoneName(local), ".", "\\.") + "." + zoneAbsoluteName(domain)
+}
+
+// escapeZoneName escapes the characters that have a special meaning in zone files
+func escapeZoneName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == ';' || r == '(' || r == ')' || r == '"' || r == '\\' || r == '$' || r == '@':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r <= ' ' || r == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
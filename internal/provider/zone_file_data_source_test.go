@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestZoneFileDataSource_Metadata(t *testing.T) {
+	d := NewZoneFileDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pihole"}, resp)
+
+	if resp.TypeName != "pihole_zone_file" {
+		t.Errorf("Expected TypeName to be 'pihole_zone_file', got '%s'", resp.TypeName)
+	}
+}
+
+func TestRenderZoneFile(t *testing.T) {
+	records := []DNSRecord{
+		{Domain: "nas.homelab.local", IP: "192.168.1.20"},
+		{Domain: "homelab.local", IP: "192.168.1.2"},
+		{Domain: "nas.homelab.local", IP: "fd00::20"},
+		{Domain: "printer.other.local", IP: "192.168.1.30"},
+	}
+	cnameRecords := []CNAMERecord{
+		{Domain: "www.homelab.local", Target: "nas.homelab.local"},
+		{Domain: "cdn.homelab.local", Target: "cdn.example.com"},
+		{Domain: "homelab.local", Target: "nas.homelab.local"},
+	}
+	soa := zoneFileSOA{
+		PrimaryNS:  "ns1.homelab.local",
+		AdminEmail: "first.last@example.com",
+		Serial:     2026101501,
+		Refresh:    86400,
+		Retry:      7200,
+		Expire:     3600000,
+		Minimum:    3600,
+	}
+
+	expected := strings.Join([]string{
+		"$ORIGIN homelab.local.",
+		"$TTL 300",
+		"@\tIN\tSOA\tns1.homelab.local. first\\.last.example.com. (",
+		"\t\t2026101501\t; serial",
+		"\t\t86400\t; refresh",
+		"\t\t7200\t; retry",
+		"\t\t3600000\t; expire",
+		"\t\t3600 )\t; minimum",
+		"@\tIN\tNS\tns1.homelab.local.",
+		"@\tIN\tA\t192.168.1.2",
+		"cdn\tIN\tCNAME\tcdn.example.com.",
+		"nas\tIN\tA\t192.168.1.20",
+		"nas\tIN\tAAAA\tfd00::20",
+		"www\tIN\tCNAME\tnas",
+		"",
+	}, "\n")
+
+	if content := renderZoneFile("homelab.local", 300, soa, records, cnameRecords); content != expected {
+		t.Errorf("Unexpected zone file:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestEscapeZoneName(t *testing.T) {
+	testCases := map[string]string{
+		"nas":         "nas",
+		"my host":     `my\032host`,
+		"semi;colon":  `semi\;colon`,
+		`back\slash`:  `back\\slash`,
+		"(paren)":     `\(paren\)`,
+		"_srv._tcp":   "_srv._tcp",
+		`quote"d`:     `quote\"d`,
+		"at@sign":     `at\@sign`,
+		"dollar$sign": `dollar\$sign`,
+	}
+
+	for input, expected := range testCases {
+		if result := escapeZoneName(input); result != expected {
+			t.Errorf("For '%s': expected '%s', got '%s'", input, expected, result)
+		}
+	}
+}
+
+func TestZoneFileDataSource_ReadAppliesDefaults(t *testing.T) {
+	server := createMockPiholeServer()
+	defer server.Close()
+
+	d := NewZoneFileDataSource()
+	d.(*ZoneFileDataSource).client = newTestClient(t, server.URL)
+
+	resp := testDataSourceRead(t, d, map[string]tftypes.Value{
+		"origin": tftypes.NewValue(tftypes.String, "example.com."),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read has errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var data ZoneFileDataSourceModel
+	resp.State.Get(context.Background(), &data)
+
+	content := data.Content.ValueString()
+	for _, line := range []string{
+		"$ORIGIN example.com.",
+		"$TTL 3600",
+		"@\tIN\tSOA\tns1.example.com. hostmaster.example.com. (",
+		"\t\t1\t; serial",
+		"test\tIN\tA\t192.168.1.100",
+		"server\tIN\tA\t192.168.1.101",
+		"www\tIN\tCNAME\texample.com.",
+		"mail\tIN\tCNAME\tserver",
+	} {
+		if !strings.Contains(content, line+"\n") {
+			t.Errorf("Expected zone file to contain %q, got:\n%s", line, content)
+		}
+	}
+	if data.ID.ValueString() != "example.com" || data.TTL.ValueInt64() != defaultZoneTTL {
+		t.Errorf("Expected id example.com and default ttl, got %s/%s", data.ID, data.TTL)
+	}
+}